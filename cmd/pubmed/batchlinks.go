@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// batchLinksHelp is appended to the Long help of the link commands.
+const batchLinksHelp = `
+Several PMIDs may be given as arguments or comma-separated lists, and "-"
+reads more from stdin (one per line, or comma/space separated). All of them
+are looked up with a single ELink request. JSON output then maps each source
+PMID to its links; --aggregate instead unions the links of all sources and
+counts how many sources link to each article, most shared first.
+
+  pubmed cited-by 38000001,38000002 --json
+  cat pmids.txt | pubmed references - --aggregate --human`
+
+// linkInputPMIDs collects PMIDs from args, which may be comma-separated
+// lists or "-" for stdin, dropping duplicates.
+func linkInputPMIDs(args []string, stdin io.Reader) ([]string, error) {
+	seen := make(map[string]bool)
+	var pmids []string
+	for _, arg := range args {
+		var ids []string
+		var err error
+		if arg == "-" {
+			ids, err = readPMIDFile("-", stdin)
+		} else {
+			ids, err = parsePMIDArg(arg)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				pmids = append(pmids, id)
+			}
+		}
+	}
+	if len(pmids) == 0 {
+		return nil, fmt.Errorf("no PMIDs given")
+	}
+	return pmids, nil
+}
+
+// formatLinkBatch prints link results for several source PMIDs, or their
+// aggregate with --aggregate. Human tables and RIS exports of aggregates
+// cover the first --limit articles; per-source RIS exports cover the first
+// --limit links of each source.
+func formatLinkBatch(cmd *cobra.Command, client *eutils.Client, pmids []string, results []eutils.LinkResult, linkType string) error {
+	cfg := outputCfg()
+
+	if flagAggregate {
+		links := eutils.AggregateLinks(results)
+		var articleMap map[string]eutils.Article
+		if cfg.Human || cfg.RISFile != "" {
+			ids := make([]string, 0, min(flagLimit, len(links)))
+			for _, l := range links[:min(flagLimit, len(links))] {
+				ids = append(ids, l.ID)
+			}
+			articles, err := exportLinkedArticles(cmd, client, ids, cfg)
+			if err != nil {
+				return err
+			}
+			articleMap = make(map[string]eutils.Article, len(articles))
+			for _, a := range articles {
+				articleMap[a.PMID] = a
+			}
+		}
+		return output.FormatAggregatedLinks(os.Stdout, pmids, links, linkType, articleMap, flagLimit, cfg)
+	}
+
+	if cfg.RISFile != "" {
+		seen := make(map[string]bool)
+		var ids []string
+		for _, r := range results {
+			for _, l := range r.Links[:min(flagLimit, len(r.Links))] {
+				if !seen[l.ID] {
+					seen[l.ID] = true
+					ids = append(ids, l.ID)
+				}
+			}
+		}
+		if _, err := exportLinkedArticles(cmd, client, ids, cfg); err != nil {
+			return err
+		}
+	}
+	return output.FormatLinkResults(os.Stdout, results, linkType, cfg)
+}
+
+// exportLinkedArticles retrieves the articles for ids, writing them to the
+// RIS file when one is requested. A failed lookup is fatal only for RIS;
+// human output falls back to PMIDs with a warning.
+func exportLinkedArticles(cmd *cobra.Command, client *eutils.Client, ids []string, cfg output.OutputConfig) ([]eutils.Article, error) {
+	var (
+		articles []eutils.Article
+		err      error
+	)
+	if len(ids) > 0 {
+		articles, err = linkedArticles(cmd.Context(), client, ids, cfg.RISFile != "")
+	}
+	if cfg.RISFile != "" {
+		if err != nil {
+			return nil, fmt.Errorf("failed to export RIS: %w", err)
+		}
+		if err := output.FormatArticles(io.Discard, articles, output.OutputConfig{RISFile: cfg.RISFile}); err != nil {
+			return nil, fmt.Errorf("RIS export failed: %w", err)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: article details unavailable: %v\n", err)
+	}
+	return articles, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/ncbi"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and manage the NCBI response cache",
+	Long: `Inspect and manage the on-disk cache of NCBI responses.
+
+Responses are keyed on endpoint and query parameters (excluding api_key,
+tool and email) and expire per endpoint: esearch after 1 hour, elink after
+1 day, efetch and esummary after 7 days. Use --cache-dir to choose a
+different location and --no-cache on any command to bypass the cache.`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache location, entry count, and size",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := openCache()
+		if err != nil {
+			return err
+		}
+		stats, err := cache.Stats()
+		if err != nil {
+			return fmt.Errorf("reading cache stats: %w", err)
+		}
+
+		w := cmd.OutOrStdout()
+		if flagJSON {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(stats)
+		}
+		fmt.Fprintf(w, "Cache directory: %s\n", stats.Dir)
+		fmt.Fprintf(w, "Entries: %d (%d expired)\n", stats.Entries, stats.Expired)
+		fmt.Fprintf(w, "Size: %s of %s\n", formatBytes(stats.Bytes), formatBytes(stats.MaxBytes))
+		return nil
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached responses",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := openCache()
+		if err != nil {
+			return err
+		}
+		removed, err := cache.Clear()
+		if err != nil {
+			return fmt.Errorf("clearing cache: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Removed %d cached responses from %s\n", removed, cache.Dir)
+		return nil
+	},
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove expired responses and enforce the size cap",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache, err := openCache()
+		if err != nil {
+			return err
+		}
+		removed, err := cache.Prune()
+		if err != nil {
+			return fmt.Errorf("pruning cache: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Pruned %d cached responses from %s\n", removed, cache.Dir)
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cachePruneCmd)
+}
+
+func openCache() (*ncbi.Cache, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return ncbi.NewCache(dir), nil
+}
+
+// formatBytes renders a byte count using binary units.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/idconv"
+	"github.com/henrybloomingdale/pubmed-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var flagNoFallback bool
+
+var convertCmd = &cobra.Command{
+	Use:   "convert [id...]",
+	Short: "Convert between PMIDs, PMCIDs, DOIs, and manuscript IDs",
+	Long: `Map article identifiers to each other in batch using the NCBI PMC ID
+Converter. IDs may be PMIDs, PMCIDs (PMC1234567), DOIs (10.xxxx/..., with or
+without a doi: or https://doi.org/ prefix), or NIH Manuscript IDs (NIHMS...),
+and may be mixed freely.
+
+With no arguments, or "-", IDs are read from stdin separated by newlines,
+commas or whitespace; lines starting with # are ignored.
+
+The converter only knows articles in PMC. DOIs it cannot map are looked up
+with an ESearch [doi] query, and PMIDs are filled in from ESummary, unless
+--no-fallback is given. The Source column tells which service answered.
+
+  pubmed convert 37286542 PMC10245678 10.1186/s13229-023-00555-1
+  cut -f1 dois.txt | pubmed convert --csv ids.csv`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids, err := convertInput(args, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return fmt.Errorf("no IDs given; pass them as arguments or on stdin")
+		}
+
+		var opts []idconv.Option
+		if flagNoFallback {
+			opts = append(opts, idconv.WithoutFallback())
+		}
+		records, err := idconv.NewClient(newBaseClient(), opts...).Convert(cmd.Context(), ids)
+		if err != nil {
+			return fmt.Errorf("conversion failed: %w", err)
+		}
+
+		return output.FormatIDRecords(os.Stdout, records, outputCfg())
+	},
+}
+
+// convertInput returns the IDs given as arguments, reading stdin when
+// there are none or an argument is "-".
+func convertInput(args []string, stdin io.Reader) ([]string, error) {
+	if len(args) == 0 {
+		args = []string{"-"}
+	}
+	var ids []string
+	for _, arg := range args {
+		if arg != "-" {
+			ids = append(ids, splitIDList(arg)...)
+			continue
+		}
+		scanner := bufio.NewScanner(stdin)
+		for scanner.Scan() {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			ids = append(ids, splitIDList(text)...)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("reading IDs from stdin: %w", err)
+		}
+	}
+	return ids, nil
+}
+
+// splitIDList splits s on commas and whitespace.
+func splitIDList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+func init() {
+	convertCmd.Flags().BoolVar(&flagNoFallback, "no-fallback", false, "Use only the ID converter, without E-utilities lookups")
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"strings"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagExportFormat string
+	flagExportOut    string
+	flagExportMax    int
+	flagExportFile   string
+	flagQueryKey     string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [query]",
+	Short: "Stream full records for a large result set to NDJSON, CSV, or RIS",
+	Long: `Export full PubMed records without holding the result set in memory.
+EFetch responses are decoded record by record and written as they arrive,
+so tens of thousands of articles use no more memory than one.
+
+Records come from one of:
+  - a query, run with --year/--type/--sort like search (all hits, or --max)
+  - --webenv and --query-key, e.g. a set uploaded with 'pubmed post'
+  - --file, a list of PMIDs ("-" for stdin)
+
+  pubmed export 'fragile x syndrome' --format csv --out fxs.csv
+  pubmed export --webenv <WebEnv> --query-key 1 --format ris --out set.ris`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagExportMax < 0 {
+			return fmt.Errorf("--max must be 0 or greater")
+		}
+		sources := 0
+		if len(args) > 0 {
+			sources++
+		}
+		if flagQueryKey != "" {
+			sources++
+		}
+		if flagExportFile != "" {
+			sources++
+		}
+		if sources != 1 {
+			return fmt.Errorf("give exactly one of: a query, --query-key (with --webenv), or --file")
+		}
+
+		client := newEutilsClient()
+		ctx := cmd.Context()
+
+		var records iter.Seq2[eutils.Article, error]
+		switch {
+		case flagExportFile != "":
+			pmids, err := readPMIDFile(flagExportFile, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			if len(pmids) == 0 {
+				return fmt.Errorf("no PMIDs found in %s", flagExportFile)
+			}
+			if flagExportMax > 0 && len(pmids) > flagExportMax {
+				pmids = pmids[:flagExportMax]
+			}
+			records = client.FetchStream(ctx, pmids)
+
+		case flagQueryKey != "":
+			if flagWebEnv == "" {
+				return fmt.Errorf("--query-key requires --webenv")
+			}
+			records = client.FetchHistoryStream(ctx, flagWebEnv, flagQueryKey, 0, flagExportMax)
+
+		default:
+			opts := &eutils.SearchOptions{Limit: 1, Sort: strings.ToLower(flagSort), WebEnv: flagWebEnv}
+			if flagYear != "" {
+				minDate, maxDate, err := parseYearRange(flagYear)
+				if err != nil {
+					return fmt.Errorf("invalid --year value %q: %w", flagYear, err)
+				}
+				opts.MinDate = minDate
+				opts.MaxDate = maxDate
+			}
+			result, err := client.Search(ctx, buildQuery(args), opts)
+			if err != nil {
+				return fmt.Errorf("search failed: %w", err)
+			}
+			if result.Count == 0 {
+				fmt.Fprintln(os.Stderr, "No results found; nothing to export.")
+				return nil
+			}
+			max := result.Count
+			if flagExportMax > 0 && flagExportMax < max {
+				max = flagExportMax
+			}
+			records = client.FetchHistoryStream(ctx, result.WebEnv, result.QueryKey, 0, max)
+		}
+
+		w := cmd.OutOrStdout()
+		if flagExportOut != "" && flagExportOut != "-" {
+			f, err := os.Create(flagExportOut)
+			if err != nil {
+				return fmt.Errorf("creating export file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		n, err := writeArticleStream(w, flagExportFormat, records)
+		if err != nil {
+			return fmt.Errorf("export failed after %d records: %w", n, err)
+		}
+		if flagExportOut != "" && flagExportOut != "-" {
+			fmt.Fprintf(os.Stderr, "Exported %d records to %s\n", n, flagExportOut)
+		}
+		return nil
+	},
+}
+
+// writeArticleStream writes each record from records to w as it arrives and
+// returns the number written. Records written before an error are flushed.
+func writeArticleStream(w io.Writer, format string, records iter.Seq2[eutils.Article, error]) (int, error) {
+	stream, err := output.NewArticleStream(w, format)
+	if err != nil {
+		return 0, err
+	}
+	for a, err := range records {
+		if err != nil {
+			stream.Close()
+			return stream.Count(), err
+		}
+		if err := stream.Write(a); err != nil {
+			stream.Close()
+			return stream.Count(), err
+		}
+	}
+	return stream.Count(), stream.Close()
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&flagExportFormat, "format", output.StreamNDJSON, "Output format: ndjson, csv, or ris")
+	exportCmd.Flags().StringVarP(&flagExportOut, "out", "o", "", `Output file (default stdout)`)
+	exportCmd.Flags().IntVar(&flagExportMax, "max", 0, "Export at most this many records (0 = all)")
+	exportCmd.Flags().StringVar(&flagExportFile, "file", "", `File of PMIDs to export ("-" for stdin)`)
+	exportCmd.Flags().StringVar(&flagQueryKey, "query-key", "", "Export a history-server set by query key (requires --webenv)")
+	exportCmd.Flags().StringVar(&flagWebEnv, "webenv", "", "History session for --query-key, or to search within")
+}
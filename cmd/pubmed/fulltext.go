@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/output"
+	"github.com/henrybloomingdale/pubmed-cli/internal/pmc"
+	"github.com/henrybloomingdale/pubmed-cli/internal/refcheck"
+	"github.com/spf13/cobra"
+)
+
+var flagFullTextFormat string
+
+var fulltextCmd = &cobra.Command{
+	Use:   "fulltext <pmid|pmcid>",
+	Short: "Retrieve PMC full text as plain text, Markdown, or JSON",
+	Long: `Retrieve an article's full text from PubMed Central and print its sections,
+figure captions, tables, and reference list.
+
+Give a PMCID (PMC1234567) or a PMID; PMIDs are mapped to their PMCID via
+ESummary. Only articles deposited in PMC have full text, and some publishers
+allow only the front matter and references to be downloaded as XML.
+
+  pubmed fulltext PMC10245678 --format markdown > article.md
+  pubmed fulltext 37286542 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		base := newBaseClient()
+		pmcid, err := resolvePMCID(cmd.Context(), eutils.NewClientWithBase(base), args[0])
+		if err != nil {
+			return err
+		}
+
+		article, err := pmc.NewClient(base).Fetch(cmd.Context(), pmcid)
+		if errors.Is(err, pmc.ErrNoArticle) {
+			return fmt.Errorf("%s is not available from PMC", pmcid)
+		}
+		if err != nil {
+			return fmt.Errorf("full-text retrieval failed: %w", err)
+		}
+		if !article.HasBody() {
+			fmt.Fprintf(os.Stderr, "Warning: the publisher of %s does not allow full-text XML download; showing front matter and references only.\n", pmcid)
+		}
+
+		return output.FormatFullText(os.Stdout, article, flagFullTextFormat, outputCfg())
+	},
+}
+
+// resolvePMCID returns id as a canonical PMCID, looking up the PMCID of a
+// PMID via ESummary.
+func resolvePMCID(ctx context.Context, client *eutils.Client, id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if strings.HasPrefix(strings.ToUpper(id), "PMC") {
+		return pmc.NormalizePMCID(id)
+	}
+	if err := validatePMID(id); err != nil {
+		return "", fmt.Errorf("expected a PMID or PMCID: %w", err)
+	}
+
+	summaries, err := client.Summary(ctx, []string{id})
+	if err != nil {
+		return "", fmt.Errorf("PMCID lookup failed: %w", err)
+	}
+	if len(summaries) == 0 {
+		return "", fmt.Errorf("PMID %s not found", id)
+	}
+	if summaries[0].PMCID == "" {
+		return "", fmt.Errorf("PMID %s has no PMC full text", id)
+	}
+	return pmc.NormalizePMCID(summaries[0].PMCID)
+}
+
+// deepReferences resolves the PMC ref-list of pmid, merging in the
+// reference links already retrieved. It fails when the article has no PMC
+// full text or its ref-list is empty.
+func deepReferences(ctx context.Context, client *eutils.Client, pmid string, links *eutils.LinkResult) (*refcheck.PMCReferenceList, error) {
+	pmcid, err := resolvePMCID(ctx, client, pmid)
+	if err != nil {
+		return nil, err
+	}
+	article, err := pmc.NewClient(client.BaseClient).Fetch(ctx, pmcid)
+	if err != nil {
+		return nil, fmt.Errorf("PMC fetch for %s failed: %w", pmcid, err)
+	}
+	if len(article.References) == 0 {
+		return nil, fmt.Errorf("%s has no reference list", pmcid)
+	}
+	return refcheck.NewResolver(client).ResolvePMCReferences(ctx, pmid, article, links.Links), nil
+}
+
+// formatDeepReferences prints a resolved PMC reference list. CSV and RIS
+// exports contain the verified PubMed records, not unverified candidates.
+func formatDeepReferences(cmd *cobra.Command, client *eutils.Client, list *refcheck.PMCReferenceList) error {
+	cfg := outputCfg()
+	if cfg.CSVFile != "" || cfg.RISFile != "" {
+		var articles []eutils.Article
+		if ids := list.PMIDs(); len(ids) > 0 {
+			var err error
+			if articles, err = client.Fetch(cmd.Context(), ids); err != nil {
+				return fmt.Errorf("fetching resolved references: %w", err)
+			}
+		}
+		exportCfg := output.OutputConfig{CSVFile: cfg.CSVFile, RISFile: cfg.RISFile}
+		if err := output.FormatArticles(io.Discard, articles, exportCfg); err != nil {
+			return err
+		}
+	}
+	if cfg.JSON {
+		return refcheck.FormatPMCReferencesJSON(os.Stdout, list)
+	}
+	return refcheck.FormatPMCReferencesText(os.Stdout, list)
+}
+
+func init() {
+	fulltextCmd.Flags().StringVar(&flagFullTextFormat, "format", output.FullTextPlain, "Output format: text, markdown, or json")
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagDB     string
+	flagLinkTo string
+)
+
+// linkTargets are the databases accepted by links --to.
+var linkTargets = []string{"gene", "protein", "pmc", "clinvar", "gds"}
+
+var fieldsCmd = &cobra.Command{
+	Use:   "fields",
+	Short: "List searchable query fields and their tags",
+	Long: `List the search fields of an Entrez database (PubMed by default) as reported
+by EInfo: the tag to use in queries (e.g. [tiab], [dp], [ad]), its full
+name, the number of indexed terms, and a description.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := newEutilsClient()
+		info, err := client.Info(cmd.Context(), flagDB)
+		if err != nil {
+			return fmt.Errorf("field lookup failed: %w", err)
+		}
+		return output.FormatDBFields(os.Stdout, info, outputCfg())
+	},
+}
+
+var linksCmd = &cobra.Command{
+	Use:   "links [pmid]",
+	Short: "List ELink link names, or follow an article's links to another database",
+	Long: `Without arguments, list the ELink link names available from an Entrez
+database (PubMed by default) as reported by EInfo, with the target database
+and a description.
+
+With a PMID and --to, follow every link from the article into the target
+database and print the linked records, grouped by link name:
+
+  pubmed links 37286542 --to gene       # genes discussed in the article
+  pubmed links 37286542 --to protein
+  pubmed links 37286542 --to pmc        # the PMC copy, if any
+  pubmed links 37286542 --to clinvar
+  pubmed links 37286542 --to gds        # GEO datasets
+
+Linked records are labeled with ESummary (accession or symbol, title, and
+organism); --limit caps how many are summarized.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := newEutilsClient()
+		if len(args) == 0 {
+			if flagLinkTo != "" {
+				return fmt.Errorf("--to requires a PMID to link from")
+			}
+			info, err := client.Info(cmd.Context(), flagDB)
+			if err != nil {
+				return fmt.Errorf("link lookup failed: %w", err)
+			}
+			return output.FormatDBLinks(os.Stdout, info, outputCfg())
+		}
+
+		if err := validatePMID(args[0]); err != nil {
+			return fmt.Errorf("invalid PMID: %w", err)
+		}
+		dbTo, err := linkTarget(flagLinkTo)
+		if err != nil {
+			return err
+		}
+
+		sets, err := client.Link(cmd.Context(), args, flagDB, dbTo, "")
+		if err != nil {
+			return fmt.Errorf("link lookup failed: %w", err)
+		}
+
+		var records []eutils.RecordSummary
+		if ids := sets[0].IDs(); len(ids) > 0 {
+			if len(ids) > flagLimit {
+				ids = ids[:flagLimit]
+			}
+			records, err = client.RecordSummaries(cmd.Context(), dbTo, ids)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s summaries unavailable: %v\n", dbTo, err)
+			}
+		}
+		return output.FormatLinkSets(os.Stdout, sets, records, outputCfg())
+	},
+}
+
+// linkTarget validates the --to database.
+func linkTarget(db string) (string, error) {
+	db = strings.ToLower(strings.TrimSpace(db))
+	if db == "" {
+		return "", fmt.Errorf("--to is required with a PMID; use one of: %s", strings.Join(linkTargets, ", "))
+	}
+	for _, t := range linkTargets {
+		if db == t {
+			return db, nil
+		}
+	}
+	return "", fmt.Errorf("--to must be one of: %s", strings.Join(linkTargets, ", "))
+}
+
+func init() {
+	fieldsCmd.Flags().StringVar(&flagDB, "db", "pubmed", "Entrez database to describe")
+	linksCmd.Flags().StringVar(&flagDB, "db", "pubmed", "Entrez database to describe or link from")
+	linksCmd.Flags().StringVar(&flagLinkTo, "to", "", "Follow links to this database: "+strings.Join(linkTargets, ", "))
+}
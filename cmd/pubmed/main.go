@@ -2,11 +2,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
 	"github.com/henrybloomingdale/pubmed-cli/internal/mesh"
@@ -26,6 +30,30 @@ var (
 	flagYear   string
 	flagType   string
 	flagAPIKey string
+
+	flagNoCache  bool
+	flagCacheDir string
+
+	flagMaxRetries   int
+	flagRetryMaxWait time.Duration
+	flagDebug        bool
+
+	flagBatchSize int
+
+	flagAll    bool
+	flagOffset int
+	flagWebEnv string
+
+	flagAutoCorrect bool
+	flagDeep        bool
+	flagAggregate   bool
+
+	flagOffline  bool
+	flagMeshFile string
+
+	flagExpandMesh   bool
+	flagExplode      bool
+	flagMeshHeadings []string
 )
 
 const (
@@ -73,6 +101,27 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&flagYear, "year", "", "Filter by year range (e.g., 2020-2025)")
 	rootCmd.PersistentFlags().StringVar(&flagType, "type", "", "Filter by publication type (review, trial, meta-analysis)")
 	rootCmd.PersistentFlags().StringVar(&flagAPIKey, "api-key", "", "NCBI API key (or set NCBI_API_KEY env var)")
+	rootCmd.PersistentFlags().BoolVar(&flagNoCache, "no-cache", false, "Bypass the on-disk NCBI response cache")
+	rootCmd.PersistentFlags().StringVar(&flagCacheDir, "cache-dir", "", "Response cache directory (default: user cache dir/pubmed-cli)")
+	rootCmd.PersistentFlags().IntVar(&flagMaxRetries, "max-retries", ncbi.DefaultRetryPolicy().MaxRetries, "Retries for transient NCBI failures (HTTP 429/5xx, network errors)")
+	rootCmd.PersistentFlags().DurationVar(&flagRetryMaxWait, "retry-max-wait", ncbi.DefaultRetryPolicy().MaxWait, "Maximum wait between retries, including Retry-After")
+	rootCmd.PersistentFlags().BoolVar(&flagDebug, "debug", false, "Log retries and other request diagnostics to stderr")
+
+	searchCmd.Flags().BoolVar(&flagAll, "all", false, "Retrieve all results, paging past --limit")
+	searchCmd.Flags().IntVar(&flagOffset, "offset", 0, "Skip this many results before returning IDs")
+	searchCmd.Flags().BoolVar(&flagAutoCorrect, "auto-correct", false, "Rerun zero-hit queries with the ESpell suggestion")
+	searchCmd.Flags().StringVar(&flagWebEnv, "webenv", "", "Search within an existing history session (reference sets as #<query_key>)")
+	searchCmd.Flags().BoolVar(&flagExpandMesh, "expand-mesh", false, "Rewrite free-text terms as MeSH headings OR title/abstract synonyms")
+	searchCmd.Flags().BoolVar(&flagExplode, "explode", true, "Include narrower MeSH terms in expanded headings (false emits [mh:noexp])")
+	searchCmd.Flags().StringArrayVar(&flagMeshHeadings, "mesh", nil, `Require a MeSH heading, e.g. "Fragile X Syndrome/genetics" (repeatable)`)
+	searchCmd.Flags().StringVar(&flagMeshFile, "mesh-file", "", "NLM MeSH descriptor file for --expand-mesh and --mesh (or set PUBMED_MESH_FILE)")
+	referencesCmd.Flags().BoolVar(&flagDeep, "deep", false, "Resolve the PMC full-text reference list when available")
+	for _, c := range []*cobra.Command{citedByCmd, referencesCmd, relatedCmd} {
+		c.Flags().BoolVar(&flagAggregate, "aggregate", false, "Union the links of all PMIDs, counting the sources that link to each")
+	}
+	fetchCmd.Flags().IntVar(&flagBatchSize, "batch-size", eutils.DefaultBatchSize, "PMIDs per EFetch request")
+	meshCmd.PersistentFlags().BoolVar(&flagOffline, "offline", false, "Use only the local MeSH descriptor file, never NCBI")
+	meshCmd.PersistentFlags().StringVar(&flagMeshFile, "mesh-file", "", "NLM MeSH descriptor file (desc XML or ASCII .bin; or set PUBMED_MESH_FILE)")
 
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(fetchCmd)
@@ -80,7 +129,14 @@ func init() {
 	rootCmd.AddCommand(referencesCmd)
 	rootCmd.AddCommand(relatedCmd)
 	rootCmd.AddCommand(meshCmd)
+	rootCmd.AddCommand(fieldsCmd)
+	rootCmd.AddCommand(linksCmd)
 	rootCmd.AddCommand(refcheckCmd)
+	rootCmd.AddCommand(postCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(fulltextCmd)
+	rootCmd.AddCommand(convertCmd)
+	rootCmd.AddCommand(cacheCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
@@ -103,15 +159,63 @@ func newBaseClient() *ncbi.BaseClient {
 	if apiKey != "" {
 		opts = append(opts, ncbi.WithAPIKey(apiKey))
 	}
+	if !flagNoCache {
+		if dir, err := cacheDir(); err == nil {
+			opts = append(opts, ncbi.WithCache(ncbi.NewCache(dir)))
+		}
+	}
+
+	retry := ncbi.DefaultRetryPolicy()
+	retry.MaxRetries = flagMaxRetries
+	retry.MaxWait = flagRetryMaxWait
+	opts = append(opts, ncbi.WithRetryPolicy(retry))
+
+	if flagDebug {
+		opts = append(opts, ncbi.WithLogger(log.New(os.Stderr, "[debug] ", log.Ltime|log.Lmicroseconds)))
+	}
 	return ncbi.NewBaseClient(opts...)
 }
 
+// cacheDir resolves the response cache directory from --cache-dir or the
+// platform user cache directory.
+func cacheDir() (string, error) {
+	if flagCacheDir != "" {
+		return flagCacheDir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating user cache directory: %w", err)
+	}
+	return filepath.Join(base, projectName), nil
+}
+
 func newEutilsClient() *eutils.Client {
 	return eutils.NewClientWithBase(newBaseClient())
 }
 
-func newMeshClient() *mesh.Client {
-	return mesh.NewClient(newBaseClient())
+// newMeshClient returns a MeSH client, served from a local descriptor file
+// when --mesh-file or PUBMED_MESH_FILE names one. --offline requires it.
+func newMeshClient() (*mesh.Client, error) {
+	path := flagMeshFile
+	if path == "" {
+		path = os.Getenv("PUBMED_MESH_FILE")
+	}
+	if path == "" {
+		if flagOffline {
+			return nil, fmt.Errorf("--offline requires a MeSH descriptor file; set --mesh-file or PUBMED_MESH_FILE")
+		}
+		return mesh.NewClient(newBaseClient()), nil
+	}
+
+	var indexDir string
+	if dir, err := cacheDir(); err == nil {
+		indexDir = filepath.Join(dir, "mesh")
+	}
+	store, err := mesh.OpenStore(path, indexDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading MeSH file: %w", err)
+	}
+	return mesh.NewClient(newBaseClient(), mesh.WithStore(store)), nil
 }
 
 func buildQuery(args []string) string {
@@ -136,6 +240,29 @@ func buildQuery(args []string) string {
 	return query
 }
 
+// meshHeadingQuery ANDs query with a [mh] clause for each --mesh heading,
+// after checking the descriptor and its qualifiers against MeSH.
+func meshHeadingQuery(ctx context.Context, meshClient *mesh.Client, query string) (string, error) {
+	if len(flagMeshHeadings) == 0 {
+		return query, nil
+	}
+	var clauses []string
+	if query = strings.TrimSpace(query); query != "" {
+		if strings.ContainsAny(query, " \t") {
+			query = "(" + query + ")"
+		}
+		clauses = append(clauses, query)
+	}
+	for _, s := range flagMeshHeadings {
+		h, err := meshClient.ResolveHeading(ctx, s)
+		if err != nil {
+			return "", fmt.Errorf("invalid --mesh %q: %w", s, err)
+		}
+		clauses = append(clauses, h.Query(flagExplode))
+	}
+	return strings.Join(clauses, " AND "), nil
+}
+
 func parseYearRange(value string) (string, string, error) {
 	parts := strings.SplitN(strings.TrimSpace(value), "-", 2)
 	if len(parts) == 0 || parts[0] == "" {
@@ -173,6 +300,22 @@ func validateGlobalFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("--limit must be greater than 0")
 	}
 
+	if flagMaxRetries < 0 {
+		return fmt.Errorf("--max-retries must be 0 or greater")
+	}
+
+	if flagRetryMaxWait < 0 {
+		return fmt.Errorf("--retry-max-wait must not be negative")
+	}
+
+	if flagOffset < 0 {
+		return fmt.Errorf("--offset must be 0 or greater")
+	}
+
+	if flagBatchSize <= 0 {
+		return fmt.Errorf("--batch-size must be greater than 0")
+	}
+
 	if flagSort != "" {
 		if _, ok := allowedSorts[strings.ToLower(flagSort)]; !ok {
 			return fmt.Errorf("--sort must be one of: relevance, date, cited")
@@ -187,7 +330,7 @@ func validateGlobalFlags(cmd *cobra.Command) error {
 
 	if flagRIS != "" {
 		switch cmd.Name() {
-		case "search", "mesh":
+		case "search", "mesh", "tree", "fields", "links", "fulltext", "convert":
 			return fmt.Errorf("--ris is not supported for %q; use fetch, cited-by, references, or related", cmd.Name())
 		}
 	}
@@ -255,16 +398,75 @@ func normalizePMIDArgs(args []string) ([]string, error) {
 var searchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search PubMed with Boolean/MeSH queries",
-	Long:  `Search PubMed using Boolean operators and MeSH terms. Returns PMIDs and result counts.`,
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Search PubMed using Boolean operators and MeSH terms. Returns PMIDs and result counts.
+
+Use --offset to skip into the result set and --all to page through every
+result (up to PubMed's 10,000-record retrieval limit) via the history server.
+Use --webenv with a handle from 'pubmed post' to combine uploaded PMID sets
+with a query, e.g. '#1 AND autism[mh]'.
+
+When a query returns no results, a spelling-corrected query from ESpell is
+suggested (suggested_query in --json); --auto-correct reruns it.
+
+--expand-mesh maps each free-text term to a MeSH descriptor and searches
+("Descriptor"[mh] OR "synonym"[tiab] ...) instead, printing the expanded
+query and why any term was kept as written (expansion in --json). Tagged
+terms, operators, #N references and truncated terms are not changed, and a
+term is only mapped when it is the descriptor's name or an entry term.
+Unquoted words that do not map together ("fragile x syndrome autism") are
+split into the longest phrases that do.
+--explode=false emits [mh:noexp]. Descriptors come from --mesh-file or
+PUBMED_MESH_FILE when set, otherwise from NCBI.
+
+--mesh adds a MeSH heading the results must be indexed with, optionally
+with qualifiers: --mesh "Fragile X Syndrome/genetics" searches
+"Fragile X Syndrome/genetics"[mh]. Qualifiers may be names or two-letter
+abbreviations (/GE) and must be allowed for the descriptor; several
+qualifiers (/genetics/therapy) are ORed. The query is optional with --mesh,
+and --explode=false applies here too.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(flagMeshHeadings) > 0 {
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := newEutilsClient()
-		query := buildQuery(args)
 		cfg := outputCfg()
 
+		if cmd.Flags().Changed("explode") && !flagExpandMesh && len(flagMeshHeadings) == 0 {
+			return fmt.Errorf("--explode requires --expand-mesh or --mesh")
+		}
+		query := strings.Join(args, " ")
+		var (
+			meshClient *mesh.Client
+			expansion  *mesh.Expansion
+			err        error
+		)
+		// One MeSH client serves both --expand-mesh and --mesh, so a
+		// descriptor file is loaded only once.
+		if flagExpandMesh || len(flagMeshHeadings) > 0 {
+			if meshClient, err = newMeshClient(); err != nil {
+				return err
+			}
+		}
+		if flagExpandMesh {
+			expansion, err = meshClient.ExpandQuery(cmd.Context(), query, mesh.ExpandOptions{NoExplode: !flagExplode})
+			if err != nil {
+				return fmt.Errorf("MeSH expansion failed: %w", err)
+			}
+			query = expansion.Query
+		}
+		if query, err = meshHeadingQuery(cmd.Context(), meshClient, query); err != nil {
+			return err
+		}
+		query = buildQuery([]string{query})
+
 		opts := &eutils.SearchOptions{
-			Limit: flagLimit,
-			Sort:  strings.ToLower(flagSort),
+			Limit:    flagLimit,
+			RetStart: flagOffset,
+			Sort:     strings.ToLower(flagSort),
+			WebEnv:   flagWebEnv,
 		}
 
 		if flagYear != "" {
@@ -276,22 +478,59 @@ var searchCmd = &cobra.Command{
 			opts.MaxDate = maxDate
 		}
 
-		result, err := client.Search(cmd.Context(), query, opts)
+		run := func(q string) (*eutils.SearchResult, error) {
+			if flagAll {
+				return client.SearchAll(cmd.Context(), q, opts)
+			}
+			return client.Search(cmd.Context(), q, opts)
+		}
+
+		result, err := run(query)
 		if err != nil {
 			return fmt.Errorf("search failed: %w", err)
 		}
 
-		// Auto-fetch articles for --human or --csv (rich table/export)
+		// Zero hits are usually a typo; ask ESpell for a corrected query.
+		if result.Count == 0 {
+			suggestion, spellErr := client.Spell(cmd.Context(), query)
+			if spellErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not check spelling: %v\n", spellErr)
+			} else if suggestion != "" {
+				if flagAutoCorrect {
+					corrected, err := run(suggestion)
+					if err != nil {
+						return fmt.Errorf("search failed for corrected query %q: %w", suggestion, err)
+					}
+					if corrected.Count > 0 {
+						result = corrected
+					}
+				}
+				result.SuggestedQuery = suggestion
+			}
+		}
+
+		// Auto-load summaries for --human or --csv (rich table/export).
+		// Tables need only title/journal/year, so ESummary is enough, and
+		// it is pulled from the history server rather than re-sending IDs.
 		var articles []eutils.Article
 		if (cfg.Human || cfg.CSVFile != "") && len(result.IDs) > 0 {
-			articles, err = client.Fetch(cmd.Context(), result.IDs)
+			var summaries []eutils.DocSummary
+			if result.WebEnv != "" && result.QueryKey != "" {
+				summaries, err = client.SummaryHistory(cmd.Context(), result.WebEnv, result.QueryKey, result.Offset, len(result.IDs))
+			} else {
+				summaries, err = client.Summary(cmd.Context(), result.IDs)
+			}
 			if err != nil {
 				// Non-fatal: fall back to PMID-only display
 				fmt.Fprintf(os.Stderr, "Warning: could not fetch article details: %v\n", err)
-				articles = nil
+			} else {
+				articles = summaryArticles(summaries)
 			}
 		}
 
+		if expansion != nil {
+			return output.FormatExpandedSearch(os.Stdout, expansion, result, articles, cfg)
+		}
 		return output.FormatSearchResult(os.Stdout, result, articles, cfg)
 	},
 }
@@ -300,8 +539,16 @@ var searchCmd = &cobra.Command{
 var fetchCmd = &cobra.Command{
 	Use:   "fetch <pmid> [pmid...]",
 	Short: "Fetch full article details",
-	Long:  `Retrieve full article details including abstract, authors, DOI, and MeSH terms for one or more PMIDs.`,
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Retrieve full article details including abstract, authors, DOI, and MeSH terms for one or more PMIDs.
+
+Large PMID lists are fetched in concurrent chunks (--batch-size, default 200)
+under the shared rate limiter. Articles are printed in the order requested;
+PMIDs that PubMed does not return are reported on stderr. NCBI Bookshelf
+records (books and chapters) are included alongside journal articles.
+
+With --sort date, articles are sorted newest first by publication date,
+filling in a missing day from the electronic publication date.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := newEutilsClient()
 		pmids, err := normalizePMIDArgs(args)
@@ -309,29 +556,50 @@ var fetchCmd = &cobra.Command{
 			return fmt.Errorf("invalid PMID(s): %w", err)
 		}
 
-		articles, err := client.Fetch(cmd.Context(), pmids)
+		result, err := client.FetchBatch(cmd.Context(), pmids, &eutils.BatchOptions{BatchSize: flagBatchSize})
 		if err != nil {
 			return fmt.Errorf("fetch failed: %w", err)
 		}
+		if len(result.DeletedIDs) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d PMID(s) deleted from PubMed: %s\n",
+				len(result.DeletedIDs), strings.Join(result.DeletedIDs, ", "))
+		}
+		if len(result.MissingIDs) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d PMID(s) not returned by PubMed (deleted or invalid): %s\n",
+				len(result.MissingIDs), strings.Join(result.MissingIDs, ", "))
+		}
+		if strings.EqualFold(flagSort, "date") {
+			eutils.SortByPubDate(result.Articles)
+		}
 
-		return output.FormatArticles(os.Stdout, articles, outputCfg())
+		return output.FormatArticles(os.Stdout, result.Articles, outputCfg())
 	},
 }
 
 // citedByCmd implements the cited-by subcommand.
 var citedByCmd = &cobra.Command{
-	Use:   "cited-by <pmid>",
-	Short: "Find papers that cite this article",
-	Long:  `Find papers in PubMed that cite the given article.`,
-	Args:  cobra.ExactArgs(1),
+	Use:   "cited-by <pmid> [pmid...]",
+	Short: "Find papers that cite these articles",
+	Long: `Find papers in PubMed that cite the given article.
+` + batchLinksHelp,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := validatePMID(args[0]); err != nil {
+		pmids, err := linkInputPMIDs(args, cmd.InOrStdin())
+		if err != nil {
 			return fmt.Errorf("invalid PMID: %w", err)
 		}
 
 		client := newEutilsClient()
 
-		result, err := client.CitedBy(cmd.Context(), args[0])
+		if len(pmids) > 1 || flagAggregate {
+			results, err := client.CitedByBatch(cmd.Context(), pmids)
+			if err != nil {
+				return fmt.Errorf("cited-by lookup failed: %w", err)
+			}
+			return formatLinkBatch(cmd, client, pmids, results, "cited-by")
+		}
+
+		result, err := client.CitedBy(cmd.Context(), pmids[0])
 		if err != nil {
 			return fmt.Errorf("cited-by lookup failed: %w", err)
 		}
@@ -342,40 +610,79 @@ var citedByCmd = &cobra.Command{
 
 // referencesCmd implements the references subcommand.
 var referencesCmd = &cobra.Command{
-	Use:   "references <pmid>",
-	Short: "Find papers cited by this article",
-	Long:  `List the references cited by the given article.`,
-	Args:  cobra.ExactArgs(1),
+	Use:   "references <pmid> [pmid...]",
+	Short: "Find papers cited by these articles",
+	Long: `List the references cited by the given article.
+
+PubMed's reference links (pubmed_pubmed_refs) are missing for most articles.
+With --deep, an article that is open access in PMC has its JATS ref-list
+parsed instead, and each entry is resolved to a PubMed record with the same
+tiered matching as refcheck, reporting a status and confidence per entry.
+Articles without PMC full text fall back to the reference links. --deep
+takes a single PMID.
+` + batchLinksHelp,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := validatePMID(args[0]); err != nil {
+		pmids, err := linkInputPMIDs(args, cmd.InOrStdin())
+		if err != nil {
 			return fmt.Errorf("invalid PMID: %w", err)
 		}
 
 		client := newEutilsClient()
 
-		result, err := client.References(cmd.Context(), args[0])
+		if len(pmids) > 1 || flagAggregate {
+			if flagDeep {
+				return fmt.Errorf("--deep takes a single PMID and cannot be combined with --aggregate")
+			}
+			results, err := client.ReferencesBatch(cmd.Context(), pmids)
+			if err != nil {
+				return fmt.Errorf("references lookup failed: %w", err)
+			}
+			return formatLinkBatch(cmd, client, pmids, results, "references")
+		}
+
+		result, err := client.References(cmd.Context(), pmids[0])
 		if err != nil {
 			return fmt.Errorf("references lookup failed: %w", err)
 		}
 
+		if flagDeep {
+			list, err := deepReferences(cmd.Context(), client, pmids[0], result)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v; showing PubMed reference links only\n", err)
+			} else {
+				return formatDeepReferences(cmd, client, list)
+			}
+		}
+
 		return formatLinkResults(cmd, client, result, "references")
 	},
 }
 
 // relatedCmd implements the related subcommand.
 var relatedCmd = &cobra.Command{
-	Use:   "related <pmid>",
+	Use:   "related <pmid> [pmid...]",
 	Short: "Find similar articles",
-	Long:  `Find articles similar to the given article, ranked by relevance score.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Find articles similar to the given article, ranked by relevance score.
+` + batchLinksHelp,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := validatePMID(args[0]); err != nil {
+		pmids, err := linkInputPMIDs(args, cmd.InOrStdin())
+		if err != nil {
 			return fmt.Errorf("invalid PMID: %w", err)
 		}
 
 		client := newEutilsClient()
 
-		result, err := client.Related(cmd.Context(), args[0])
+		if len(pmids) > 1 || flagAggregate {
+			results, err := client.RelatedBatch(cmd.Context(), pmids)
+			if err != nil {
+				return fmt.Errorf("related articles lookup failed: %w", err)
+			}
+			return formatLinkBatch(cmd, client, pmids, results, "related")
+		}
+
+		result, err := client.Related(cmd.Context(), pmids[0])
 		if err != nil {
 			return fmt.Errorf("related articles lookup failed: %w", err)
 		}
@@ -414,7 +721,7 @@ func formatLinkResults(cmd *cobra.Command, client *eutils.Client, result *eutils
 			pmids[i] = result.Links[i].ID
 		}
 
-		articles, fetchErr = client.Fetch(cmd.Context(), pmids)
+		articles, fetchErr = linkedArticles(cmd.Context(), client, pmids, cfg.RISFile != "")
 	}
 
 	if cfg.RISFile != "" {
@@ -448,22 +755,75 @@ func formatLinkResults(cmd *cobra.Command, client *eutils.Client, result *eutils
 	return output.FormatLinksWithArticles(os.Stdout, result, articles, articleMap, linkType, limit)
 }
 
+// linkedArticles retrieves linked articles for display or export: full
+// records when full is set (RIS), otherwise ESummary records, since the
+// human tables show only title and year.
+func linkedArticles(ctx context.Context, client *eutils.Client, pmids []string, full bool) ([]eutils.Article, error) {
+	if full {
+		return client.Fetch(ctx, pmids)
+	}
+	summaries, err := client.Summary(ctx, pmids)
+	return summaryArticles(summaries), err
+}
+
+// summaryArticles converts ESummary records into partial articles for
+// table rendering and CSV export.
+func summaryArticles(summaries []eutils.DocSummary) []eutils.Article {
+	articles := make([]eutils.Article, len(summaries))
+	for i, s := range summaries {
+		articles[i] = s.Article()
+	}
+	return articles
+}
+
 // meshCmd implements the mesh subcommand.
 var meshCmd = &cobra.Command{
 	Use:   "mesh <term>",
 	Short: "Look up a MeSH term",
-	Long:  `Search for a MeSH (Medical Subject Headings) term and display its record including tree numbers, scope note, and synonyms.`,
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Search for a MeSH (Medical Subject Headings) term and display its record including tree numbers, scope note, and synonyms.
+
+A term can match several records: descriptors, qualifiers, Supplementary
+Concept Records (SCRs), and pharmacological actions. Candidates are ranked
+by exact name, then entry term, then partial and related matches, with
+descriptors first. --json lists every candidate (up to --limit) with its
+type; --human on a terminal shows a picker when there is more than one;
+otherwise the best candidate is shown.
+
+With a local copy of the NLM descriptor file (desc20XX.xml or the ASCII
+d20XX.bin, optionally gzipped) named by --mesh-file or PUBMED_MESH_FILE,
+lookups are answered offline and also accept descriptor UIs (D005600) and
+tree numbers (C10.597.606.360.320). The first run parses the file and saves
+a compact index in the cache directory. --offline fails instead of falling
+back to NCBI when no file is configured.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		client := newMeshClient()
+		client, err := newMeshClient()
+		if err != nil {
+			return err
+		}
 		term := strings.Join(args, " ")
+		cfg := outputCfg()
 
-		record, err := client.Lookup(cmd.Context(), term)
+		candidates, err := client.Search(cmd.Context(), term, flagLimit)
 		if err != nil {
 			return fmt.Errorf("MeSH lookup failed: %w", err)
 		}
+		if cfg.JSON {
+			return output.FormatMeSHCandidates(os.Stdout, candidates, cfg)
+		}
+
+		record := &candidates[0].MeSHRecord
+		if cfg.Human && len(candidates) > 1 {
+			if interactive() {
+				if record, err = pickMeSHCandidate(term, candidates); err != nil {
+					return err
+				}
+			} else {
+				fmt.Fprintf(os.Stderr, "Note: %d MeSH records match %q; showing the best, use --json to list them.\n", len(candidates), term)
+			}
+		}
 
-		return output.FormatMeSHRecord(os.Stdout, record, outputCfg())
+		return output.FormatMeSHRecord(os.Stdout, record, cfg)
 	},
 }
 
@@ -1,9 +1,17 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/mesh"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +21,35 @@ func resetGlobalFlags() {
 	flagSort = ""
 	flagRIS = ""
 	flagLimit = 20
+	flagNoCache = false
+	flagCacheDir = ""
+	flagMaxRetries = 2
+	flagRetryMaxWait = 4 * time.Second
+	flagDebug = false
+	flagBatchSize = 200
+	flagAll = false
+	flagOffset = 0
+	flagWebEnv = ""
+	flagPostFile = ""
+	flagAutoCorrect = false
+	flagDB = "pubmed"
+	flagExportFormat = "ndjson"
+	flagExportOut = ""
+	flagExportMax = 0
+	flagExportFile = ""
+	flagQueryKey = ""
+	flagFullTextFormat = "text"
+	flagDeep = false
+	flagNoFallback = false
+	flagLinkTo = ""
+	flagAggregate = false
+	flagOffline = false
+	flagMeshFile = ""
+	flagTreeDepth = 1
+	flagTreeSiblings = false
+	flagExpandMesh = false
+	flagExplode = true
+	flagMeshHeadings = nil
 }
 
 func TestBuildQuery_Basic(t *testing.T) {
@@ -156,6 +193,24 @@ func TestValidateGlobalFlags(t *testing.T) {
 		t.Fatal("expected error for descending year range")
 	}
 
+	resetGlobalFlags()
+	flagOffset = -1
+	if err := validateGlobalFlags(&cobra.Command{Use: "search"}); err == nil {
+		t.Fatal("expected error for negative --offset")
+	}
+
+	resetGlobalFlags()
+	flagBatchSize = 0
+	if err := validateGlobalFlags(&cobra.Command{Use: "fetch"}); err == nil {
+		t.Fatal("expected error for non-positive --batch-size")
+	}
+
+	resetGlobalFlags()
+	flagMaxRetries = -1
+	if err := validateGlobalFlags(&cobra.Command{Use: "search"}); err == nil {
+		t.Fatal("expected error for negative --max-retries")
+	}
+
 	resetGlobalFlags()
 	flagLimit = 5
 	flagSort = "date"
@@ -206,6 +261,201 @@ func TestNormalizePMIDArgs(t *testing.T) {
 	}
 }
 
+func TestReadPMIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.txt")
+	content := "# collaborator set\n38000001\n\n38000002, 38000003\n38000004\t38000005\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pmids, err := readPMIDFile(path, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(pmids, ","); got != "38000001,38000002,38000003,38000004,38000005" {
+		t.Errorf("unexpected PMIDs: %s", got)
+	}
+
+	pmids, err = readPMIDFile("-", strings.NewReader("111\n222\n"))
+	if err != nil {
+		t.Fatalf("unexpected error reading stdin: %v", err)
+	}
+	if len(pmids) != 2 {
+		t.Errorf("expected 2 PMIDs from stdin, got %v", pmids)
+	}
+
+	if _, err := readPMIDFile("-", strings.NewReader("123\nabc\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected line-numbered error, got %v", err)
+	}
+}
+
+func TestConvertInput(t *testing.T) {
+	ids, err := convertInput([]string{"PMC1,37286542", "-"}, strings.NewReader("# dois\ndoi:10.1/x 10.2/y\n\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(ids, "|"); got != "PMC1|37286542|doi:10.1/x|10.2/y" {
+		t.Errorf("unexpected IDs: %s", got)
+	}
+
+	ids, err = convertInput(nil, strings.NewReader("NIHMS311352\n"))
+	if err != nil || len(ids) != 1 {
+		t.Errorf("expected stdin to be read without args, got %v, %v", ids, err)
+	}
+}
+
+func TestLinkInputPMIDs(t *testing.T) {
+	pmids, err := linkInputPMIDs([]string{"38000001,38000002", "-", "38000001"}, strings.NewReader("38000003 38000002\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(pmids, ","); got != "38000001,38000002,38000003" {
+		t.Errorf("expected deduplicated PMIDs in order, got %s", got)
+	}
+
+	if _, err := linkInputPMIDs([]string{"123,abc"}, nil); err == nil {
+		t.Error("expected invalid PMID error")
+	}
+	if _, err := linkInputPMIDs([]string{"-"}, strings.NewReader("# none\n")); err == nil {
+		t.Error("expected error for empty stdin")
+	}
+}
+
+func TestNewMeshClient_Offline(t *testing.T) {
+	resetGlobalFlags()
+	t.Setenv("PUBMED_MESH_FILE", "")
+	flagCacheDir = t.TempDir()
+
+	flagOffline = true
+	if _, err := newMeshClient(); err == nil || !strings.Contains(err.Error(), "--mesh-file") {
+		t.Errorf("expected error for --offline without a MeSH file, got %v", err)
+	}
+
+	t.Setenv("PUBMED_MESH_FILE", filepath.Join("..", "..", "testdata", "mesh_desc_sample.xml"))
+	client, err := newMeshClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !client.Offline() {
+		t.Fatal("expected offline client from PUBMED_MESH_FILE")
+	}
+	record, err := client.Lookup(context.Background(), "D005600")
+	if err != nil || record.Name != "Fragile X Syndrome" {
+		t.Errorf("unexpected offline lookup: %+v, %v", record, err)
+	}
+	resetGlobalFlags()
+}
+
+func TestMeshHeadingQuery(t *testing.T) {
+	resetGlobalFlags()
+	t.Setenv("PUBMED_MESH_FILE", filepath.Join("..", "..", "testdata", "mesh_desc_sample.xml"))
+	flagCacheDir = t.TempDir()
+	ctx := context.Background()
+	client, err := newMeshClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	flagMeshHeadings = []string{"fxs/GE", "Autistic Disorder"}
+	got, err := meshHeadingQuery(ctx, client, "sleep OR melatonin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `(sleep OR melatonin) AND "Fragile X Syndrome/genetics"[mh] AND "Autistic Disorder"[mh]`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	flagExplode = false
+	flagMeshHeadings = []string{"Fragile X Syndrome/genetics"}
+	if got, err := meshHeadingQuery(ctx, client, ""); err != nil || got != `"Fragile X Syndrome/genetics"[mh:noexp]` {
+		t.Errorf("unexpected heading-only query %q, %v", got, err)
+	}
+
+	flagMeshHeadings = []string{"Fragile X Syndrome/drug therapy"}
+	if _, err := meshHeadingQuery(ctx, client, "x"); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("expected disallowed qualifier error, got %v", err)
+	}
+	resetGlobalFlags()
+}
+
+func TestLinkTarget(t *testing.T) {
+	if db, err := linkTarget(" Gene "); err != nil || db != "gene" {
+		t.Errorf("expected gene, got %q, %v", db, err)
+	}
+	if _, err := linkTarget(""); err == nil || !strings.Contains(err.Error(), "required") {
+		t.Errorf("expected missing --to error, got %v", err)
+	}
+	if _, err := linkTarget("omim"); err == nil {
+		t.Error("expected error for unsupported target")
+	}
+}
+
+func TestCacheDir_FlagOverride(t *testing.T) {
+	resetGlobalFlags()
+	flagCacheDir = "/tmp/pubmed-cache-test"
+
+	dir, err := cacheDir()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dir != "/tmp/pubmed-cache-test" {
+		t.Errorf("expected %q, got %q", "/tmp/pubmed-cache-test", dir)
+	}
+}
+
+func TestNewBaseClient_NoCache(t *testing.T) {
+	resetGlobalFlags()
+	flagCacheDir = t.TempDir()
+
+	if c := newBaseClient(); c.Cache == nil {
+		t.Error("expected cache to be enabled by default")
+	}
+
+	flagNoCache = true
+	if c := newBaseClient(); c.Cache != nil {
+		t.Error("expected --no-cache to disable the cache")
+	}
+}
+
+func TestNewBaseClient_RetryFlags(t *testing.T) {
+	resetGlobalFlags()
+	flagNoCache = true
+	flagMaxRetries = 5
+	flagRetryMaxWait = 10 * time.Second
+
+	c := newBaseClient()
+	if c.Retry.MaxRetries != 5 {
+		t.Errorf("expected 5 retries, got %d", c.Retry.MaxRetries)
+	}
+	if c.Retry.MaxWait != 10*time.Second {
+		t.Errorf("expected 10s max wait, got %v", c.Retry.MaxWait)
+	}
+	if c.Logger != nil {
+		t.Error("expected no debug logger without --debug")
+	}
+
+	flagDebug = true
+	if c := newBaseClient(); c.Logger == nil {
+		t.Error("expected debug logger with --debug")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{512, "512 B"},
+		{2048, "2.0 KiB"},
+		{200 * 1024 * 1024, "200.0 MiB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.in); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
 func TestCLIBrandingTextIncludesVersionAndURLs(t *testing.T) {
 	origVersion := version
 	version = "v1.2.3-test"
@@ -236,3 +486,59 @@ func TestCLIHelpFooterIncludesIssueLocation(t *testing.T) {
 		t.Fatalf("help footer missing issues URL: %q", footer)
 	}
 }
+
+func TestWriteArticleStream(t *testing.T) {
+	records := func(yield func(eutils.Article, error) bool) {
+		if !yield(eutils.Article{PMID: "1"}, nil) {
+			return
+		}
+		if !yield(eutils.Article{PMID: "2"}, nil) {
+			return
+		}
+		yield(eutils.Article{}, fmt.Errorf("connection reset"))
+	}
+
+	var buf bytes.Buffer
+	n, err := writeArticleStream(&buf, "ndjson", records)
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Fatalf("expected stream error, got %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 records written, got %d", n)
+	}
+	// Records before the error are flushed.
+	if got := strings.Count(buf.String(), "\n"); got != 2 {
+		t.Errorf("expected 2 NDJSON lines, got %d:\n%s", got, buf.String())
+	}
+
+	if _, err := writeArticleStream(&buf, "bibtex", records); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestResolvePMCID_Offline(t *testing.T) {
+	got, err := resolvePMCID(context.Background(), nil, "pmc10245678")
+	if err != nil || got != "PMC10245678" {
+		t.Errorf("expected PMC10245678, got %q (%v)", got, err)
+	}
+	if _, err := resolvePMCID(context.Background(), nil, "10.1000/xyz"); err == nil {
+		t.Error("expected error for a non-PMID, non-PMCID argument")
+	}
+}
+
+func TestCandidateLabel(t *testing.T) {
+	c := mesh.Candidate{
+		MeSHRecord: mesh.MeSHRecord{UI: "D018757", Name: "GABA Modulators", TreeNumbers: []string{"D27.505.519.625.375"}},
+		Type:       mesh.TypePharmacologicalAction,
+		Match:      mesh.MatchEntryTerm,
+	}
+	want := "GABA Modulators (D018757) · pharmacological action · D27.505.519.625.375 · entry term match"
+	if got := candidateLabel(c); got != want {
+		t.Errorf("candidateLabel = %q, want %q", got, want)
+	}
+
+	c = mesh.Candidate{MeSHRecord: mesh.MeSHRecord{UI: "C536400", Name: "FXTAS"}, Type: mesh.TypeSCR, Match: mesh.MatchName}
+	if got := candidateLabel(c); got != "FXTAS (C536400) · scr" {
+		t.Errorf("unexpected label %q", got)
+	}
+}
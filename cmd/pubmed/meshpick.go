@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/henrybloomingdale/pubmed-cli/internal/mesh"
+	"github.com/mattn/go-isatty"
+)
+
+// interactive reports whether stdin and stdout are both terminals, so a
+// picker can be shown.
+func interactive() bool {
+	isTerm := func(f *os.File) bool {
+		return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+	}
+	return isTerm(os.Stdin) && isTerm(os.Stdout)
+}
+
+// pickMeSHCandidate asks the user to choose one of several MeSH records.
+func pickMeSHCandidate(term string, candidates []mesh.Candidate) (*mesh.MeSHRecord, error) {
+	options := make([]huh.Option[int], len(candidates))
+	for i, c := range candidates {
+		options[i] = huh.NewOption(candidateLabel(c), i)
+	}
+
+	var choice int
+	err := huh.NewSelect[int]().
+		Title(fmt.Sprintf("%d MeSH records match %q", len(candidates), term)).
+		Options(options...).
+		Value(&choice).
+		Run()
+	if err != nil {
+		return nil, fmt.Errorf("choosing MeSH record: %w", err)
+	}
+	return &candidates[choice].MeSHRecord, nil
+}
+
+// candidateLabel describes a candidate on one line, e.g.
+// "Fragile X Syndrome (D005600) · descriptor · C10.597.606.360.320".
+func candidateLabel(c mesh.Candidate) string {
+	parts := []string{fmt.Sprintf("%s (%s)", c.Name, c.UI), strings.ReplaceAll(string(c.Type), "_", " ")}
+	if len(c.TreeNumbers) > 0 {
+		parts = append(parts, c.TreeNumbers[0])
+	}
+	if c.Match != mesh.MatchName {
+		parts = append(parts, strings.ReplaceAll(string(c.Match), "_", " ")+" match")
+	}
+	return strings.Join(parts, " · ")
+}
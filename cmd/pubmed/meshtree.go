@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/mesh"
+	"github.com/henrybloomingdale/pubmed-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagTreeDepth    int
+	flagTreeSiblings bool
+)
+
+var meshTreeCmd = &cobra.Command{
+	Use:   "tree <term>",
+	Short: "Show where a MeSH term sits in the hierarchy",
+	Long: `Show the descriptors above and below a MeSH term, to pick a broader or
+narrower heading for a search strategy. A descriptor with several tree
+numbers is shown under each of them.
+
+--depth sets how many levels of narrower terms to include (0 for all of
+them; online walks make one request per level). --siblings also lists the
+other children of each parent. --json prints the descendants as an
+adjacency list keyed by tree number.
+
+  pubmed mesh tree "intellectual disability" --depth 2 --human
+  pubmed mesh tree F03.625.164 --offline --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if flagTreeDepth < 0 {
+			return fmt.Errorf("--depth must be 0 or greater")
+		}
+		client, err := newMeshClient()
+		if err != nil {
+			return err
+		}
+		term := strings.Join(args, " ")
+		ctx := cmd.Context()
+
+		loc, err := client.Locate(ctx, term)
+		if err != nil {
+			return fmt.Errorf("MeSH tree failed: %w", err)
+		}
+		tree, err := loc.Descendants(ctx, flagTreeDepth)
+		if err != nil {
+			return fmt.Errorf("MeSH tree failed: %w", err)
+		}
+		parents, err := loc.Parents(ctx)
+		if err != nil {
+			return fmt.Errorf("MeSH tree failed: %w", err)
+		}
+		var siblings []mesh.TreeNode
+		if flagTreeSiblings {
+			if siblings, err = loc.Siblings(ctx); err != nil {
+				return fmt.Errorf("MeSH tree failed: %w", err)
+			}
+		}
+
+		return output.FormatMeSHTree(os.Stdout, tree, parents, siblings, outputCfg())
+	},
+}
+
+func init() {
+	meshTreeCmd.Flags().IntVar(&flagTreeDepth, "depth", 1, "Levels of narrower terms to show (0 for all)")
+	meshTreeCmd.Flags().BoolVar(&flagTreeSiblings, "siblings", false, "Also list terms sharing a parent")
+	meshCmd.AddCommand(meshTreeCmd)
+}
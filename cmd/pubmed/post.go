@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var flagPostFile string
+
+var postCmd = &cobra.Command{
+	Use:   "post [pmid...]",
+	Short: "Upload a PMID set to the NCBI history server",
+	Long: `Upload a set of PMIDs to the NCBI history server with EPost and print
+the resulting WebEnv and query key.
+
+PMIDs come from arguments and/or --file (one per line, or comma/space
+separated; "-" reads stdin; lines starting with # are ignored). Pass the
+printed WebEnv to search to combine the set with a query, e.g.:
+
+  pubmed post --file ids.txt
+  pubmed search --webenv <WebEnv> '#1 AND autism[mh]'
+
+Use --webenv to add the set to an existing session.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pmids, err := normalizePMIDArgs(args)
+		if err != nil {
+			return fmt.Errorf("invalid PMID(s): %w", err)
+		}
+		if flagPostFile != "" {
+			fromFile, err := readPMIDFile(flagPostFile, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			pmids = append(pmids, fromFile...)
+		}
+		if len(pmids) == 0 {
+			return fmt.Errorf("no PMIDs given; pass them as arguments or with --file")
+		}
+
+		client := newEutilsClient()
+		result, err := client.PostHistory(cmd.Context(), flagWebEnv, pmids)
+		if err != nil {
+			return fmt.Errorf("post failed: %w", err)
+		}
+		if len(result.InvalidIDs) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d PMID(s) rejected by NCBI: %s\n",
+				len(result.InvalidIDs), strings.Join(result.InvalidIDs, ", "))
+		}
+
+		w := cmd.OutOrStdout()
+		if flagJSON {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(result)
+		}
+		fmt.Fprintf(w, "WebEnv: %s\n", result.WebEnv)
+		fmt.Fprintf(w, "QueryKey: %s\n", result.QueryKey)
+		fmt.Fprintf(w, "Posted: %d PMIDs\n", result.Count)
+		return nil
+	},
+}
+
+// readPMIDFile reads PMIDs from path ("-" for stdin). IDs may be separated
+// by newlines, commas or whitespace; blank lines and # comments are skipped.
+func readPMIDFile(path string, stdin io.Reader) ([]string, error) {
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("opening PMID file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var pmids []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(text, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		for _, f := range fields {
+			if err := validatePMID(f); err != nil {
+				return nil, fmt.Errorf("%s line %d: %w", path, line, err)
+			}
+			pmids = append(pmids, f)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading PMID file: %w", err)
+	}
+	return pmids, nil
+}
+
+func init() {
+	postCmd.Flags().StringVar(&flagPostFile, "file", "", `File of PMIDs to upload ("-" for stdin)`)
+	postCmd.Flags().StringVar(&flagWebEnv, "webenv", "", "Add the set to an existing history session")
+}
@@ -0,0 +1,137 @@
+package eutils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultBatchSize is the number of PMIDs requested per EFetch call.
+	DefaultBatchSize = 200
+	// DefaultBatchConcurrency is the number of chunks fetched in parallel.
+	// Requests still pass through the shared rate limiter.
+	DefaultBatchConcurrency = 3
+)
+
+// FetchBatch retrieves articles in chunks, running chunks concurrently under
+// the client's rate limiter. Articles are returned in the caller's PMID order
+// (duplicates collapsed). PMIDs NCBI explicitly reports as deleted are listed
+// in DeletedIDs; any others it did not return — suppressed or invalid
+// records — are listed in MissingIDs.
+func (c *Client) FetchBatch(ctx context.Context, pmids []string, opts *BatchOptions) (*BatchResult, error) {
+	if len(pmids) == 0 {
+		return nil, fmt.Errorf("at least one PMID is required")
+	}
+
+	size := DefaultBatchSize
+	workers := DefaultBatchConcurrency
+	if opts != nil {
+		if opts.BatchSize > 0 {
+			size = opts.BatchSize
+		}
+		if opts.Concurrency > 0 {
+			workers = opts.Concurrency
+		}
+	}
+
+	ids := dedupeIDs(pmids)
+	chunks := chunkIDs(ids, size)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+		firstErr   error
+		byPMID     = make(map[string]Article, len(ids))
+		deletedIDs = make(map[string]struct{})
+		sem        = make(chan struct{}, workers)
+	)
+
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk []string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = ctx.Err()
+				}
+				mu.Unlock()
+				return
+			}
+
+			articles, deleted, err := c.fetchSet(ctx, chunk)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("fetching batch %d of %d: %w", i+1, len(chunks), err)
+					cancel()
+				}
+				return
+			}
+			for _, a := range articles {
+				byPMID[a.PMID] = a
+			}
+			for _, id := range deleted {
+				deletedIDs[id] = struct{}{}
+			}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	// A chunk that was in flight may have finished despite cancellation.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &BatchResult{Articles: make([]Article, 0, len(ids))}
+	for _, id := range ids {
+		if a, ok := byPMID[id]; ok {
+			result.Articles = append(result.Articles, a)
+		} else if _, ok := deletedIDs[id]; ok {
+			result.DeletedIDs = append(result.DeletedIDs, id)
+		} else {
+			result.MissingIDs = append(result.MissingIDs, id)
+		}
+	}
+	return result, nil
+}
+
+// dedupeIDs removes repeated IDs, keeping the first occurrence.
+func dedupeIDs(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
+
+// chunkIDs splits ids into consecutive slices of at most size elements.
+func chunkIDs(ids []string, size int) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunks = append(chunks, ids[start:end])
+	}
+	return chunks
+}
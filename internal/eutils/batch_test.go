@@ -0,0 +1,164 @@
+package eutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// minimalArticleSetXML renders a PubmedArticleSet for ids in reverse order,
+// mimicking NCBI returning records in an order other than requested.
+func minimalArticleSetXML(ids []string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0"?><PubmedArticleSet>`)
+	for i := len(ids) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, `<PubmedArticle><MedlineCitation><PMID>%s</PMID><Article><ArticleTitle>Article %s</ArticleTitle></Article></MedlineCitation></PubmedArticle>`, ids[i], ids[i])
+	}
+	b.WriteString(`</PubmedArticleSet>`)
+	return b.String()
+}
+
+func TestFetchBatch_OrderAndMissing(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		r.ParseForm()
+		ids := strings.Split(r.Form.Get("id"), ",")
+		if len(ids) > 2 {
+			t.Errorf("expected chunks of at most 2 IDs, got %d", len(ids))
+		}
+		var present []string
+		for _, id := range ids {
+			if id != "3" { // PMID 3 is "deleted"
+				present = append(present, id)
+			}
+		}
+		fmt.Fprint(w, minimalArticleSetXML(present))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	result, err := c.FetchBatch(context.Background(), []string{"5", "1", "3", "4", "1", "2"}, &BatchOptions{BatchSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, a := range result.Articles {
+		got = append(got, a.PMID)
+	}
+	if want := "5,1,4,2"; strings.Join(got, ",") != want {
+		t.Errorf("expected order %s, got %s", want, strings.Join(got, ","))
+	}
+	if len(result.MissingIDs) != 1 || result.MissingIDs[0] != "3" {
+		t.Errorf("expected MissingIDs [3], got %v", result.MissingIDs)
+	}
+	// 5 unique IDs in chunks of 2 -> 3 requests.
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestFetchBatch_DefaultChunking(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		r.ParseForm()
+		fmt.Fprint(w, minimalArticleSetXML(strings.Split(r.Form.Get("id"), ",")))
+	}))
+	defer srv.Close()
+
+	pmids := make([]string, DefaultBatchSize+50)
+	for i := range pmids {
+		pmids[i] = strconv.Itoa(1000 + i)
+	}
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	result, err := c.FetchBatch(context.Background(), pmids, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Articles) != len(pmids) {
+		t.Errorf("expected %d articles, got %d", len(pmids), len(result.Articles))
+	}
+	if len(result.MissingIDs) != 0 {
+		t.Errorf("expected no missing IDs, got %v", result.MissingIDs)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestFetchBatch_ChunkError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	_, err := c.FetchBatch(context.Background(), []string{"1", "2", "3"}, &BatchOptions{BatchSize: 1})
+	if err == nil {
+		t.Fatal("expected error when a chunk fails")
+	}
+	if !strings.Contains(err.Error(), "fetching batch") {
+		t.Errorf("expected batch context in error, got: %v", err)
+	}
+}
+
+func TestFetchBatch_Cancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		w.Write([]byte(minimalArticleSetXML(strings.Split(r.Form.Get("id"), ","))))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	// Workers pick the semaphore or ctx.Done at random; repeat so the
+	// ctx.Done path, which fetches nothing, is exercised.
+	for range 20 {
+		result, err := c.FetchBatch(ctx, []string{"1", "2"}, &BatchOptions{BatchSize: 1})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v (result %+v)", err, result)
+		}
+	}
+}
+
+func TestFetchBatch_DeletedIDs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<?xml version="1.0"?><PubmedArticleSet>` +
+			`<PubmedArticle><MedlineCitation><PMID>1</PMID></MedlineCitation></PubmedArticle>` +
+			`<DeleteCitation><PMID Version="1">2</PMID></DeleteCitation>` +
+			`</PubmedArticleSet>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	result, err := c.FetchBatch(context.Background(), []string{"1", "2", "3"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Articles) != 1 || result.Articles[0].PMID != "1" {
+		t.Errorf("unexpected articles: %+v", result.Articles)
+	}
+	if len(result.DeletedIDs) != 1 || result.DeletedIDs[0] != "2" {
+		t.Errorf("expected deleted [2], got %v", result.DeletedIDs)
+	}
+	if len(result.MissingIDs) != 1 || result.MissingIDs[0] != "3" {
+		t.Errorf("expected missing [3], got %v", result.MissingIDs)
+	}
+}
+
+func TestFetchBatch_Empty(t *testing.T) {
+	c := NewClient(WithAPIKey("test"))
+	if _, err := c.FetchBatch(context.Background(), nil, nil); err == nil {
+		t.Error("expected error for empty PMIDs")
+	}
+}
@@ -0,0 +1,91 @@
+package eutils
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxGetCitMatchData is the bdata length above which CitMatch switches to
+// POST to stay within URL length limits.
+const maxGetCitMatchData = 2000
+
+// CitMatch resolves bibliographic citations to PMIDs with ECitMatch, sending
+// all citations in a single request. The result maps each citation's Key to
+// its PMID; citations NCBI reports as NOT_FOUND or AMBIGUOUS are omitted.
+func (c *Client) CitMatch(ctx context.Context, citations []CitationQuery) (map[string]string, error) {
+	if len(citations) == 0 {
+		return nil, fmt.Errorf("at least one citation is required")
+	}
+
+	var b strings.Builder
+	for i, cit := range citations {
+		if i > 0 {
+			b.WriteByte('\r')
+		}
+		for _, field := range []string{cit.Journal, cit.Year, cit.Volume, cit.FirstPage, cit.Author, cit.Key} {
+			b.WriteString(citMatchField(field))
+			b.WriteByte('|')
+		}
+	}
+	bdata := b.String()
+
+	params := url.Values{}
+	params.Set("db", "pubmed")
+	params.Set("retmode", "xml")
+	params.Set("bdata", bdata)
+
+	var (
+		body []byte
+		err  error
+	)
+	if len(bdata) > maxGetCitMatchData {
+		body, err = c.DoPost(ctx, "ecitmatch.cgi", params)
+	} else {
+		body, err = c.DoGet(ctx, "ecitmatch.cgi", params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("citmatch request failed: %w", err)
+	}
+
+	return parseCitMatch(string(body)), nil
+}
+
+// parseCitMatch reads ECitMatch's pipe-delimited response, one citation per
+// line with the key in the sixth field and the PMID (or a status) in the
+// seventh.
+func parseCitMatch(body string) map[string]string {
+	matches := make(map[string]string)
+	for _, line := range strings.Split(body, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "|")
+		if len(fields) < 7 {
+			continue
+		}
+		key := strings.TrimSpace(fields[5])
+		pmid := strings.TrimSpace(fields[6])
+		if key == "" || !isDigits(pmid) {
+			continue
+		}
+		matches[key] = pmid
+	}
+	return matches
+}
+
+// citMatchField strips characters that would break ECitMatch's line format
+// and collapses runs of whitespace.
+func citMatchField(s string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(s, "|", " ")), " ")
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package eutils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCitMatch_SingleBatchedRequest(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/ecitmatch.cgi" {
+			t.Errorf("expected ecitmatch.cgi, got %s", r.URL.Path)
+		}
+		lines := strings.Split(r.URL.Query().Get("bdata"), "\r")
+		if len(lines) != 3 {
+			t.Fatalf("expected 3 citations in bdata, got %d: %q", len(lines), lines)
+		}
+		if lines[0] != "Trends Neurosci|2004|27|370|Bear|a|" {
+			t.Errorf("unexpected first citation line %q", lines[0])
+		}
+		if lines[2] != "J Odd Name|2020|1|1|X|c|" {
+			t.Errorf("expected pipes stripped from fields, got %q", lines[2])
+		}
+		fmt.Fprint(w, "Trends Neurosci|2004|27|370|Bear|a|15219735\n"+
+			"Nature|2019|1|1|Nobody|b|NOT_FOUND;INVALID_JOURNAL\n"+
+			"J Odd Name|2020|1|1|X|c|AMBIGUOUS (2 citations)\n")
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	matches, err := c.CitMatch(context.Background(), []CitationQuery{
+		{Journal: "Trends Neurosci", Year: "2004", Volume: "27", FirstPage: "370", Author: "Bear", Key: "a"},
+		{Journal: "Nature", Year: "2019", Volume: "1", FirstPage: "1", Author: "Nobody", Key: "b"},
+		{Journal: "J Odd | Name", Year: "2020", Volume: "1", FirstPage: "1", Author: "X", Key: "c"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+	if len(matches) != 1 || matches["a"] != "15219735" {
+		t.Errorf("expected only a→15219735, got %v", matches)
+	}
+}
+
+func TestCitMatch_Empty(t *testing.T) {
+	c := NewClient(WithAPIKey("test"))
+	if _, err := c.CitMatch(context.Background(), nil); err == nil {
+		t.Error("expected error for empty citation list")
+	}
+}
@@ -1,6 +1,10 @@
 package eutils
 
 import (
+	"context"
+	"net/url"
+	"strings"
+
 	"github.com/henrybloomingdale/pubmed-cli/internal/ncbi"
 )
 
@@ -11,6 +15,10 @@ const (
 	DefaultTool = ncbi.DefaultTool
 	// DefaultEmail is the contact email sent to NCBI.
 	DefaultEmail = ncbi.DefaultEmail
+
+	// MaxGetIDs is the number of IDs above which multi-ID requests switch
+	// from GET to POST to stay within URL length limits, per NCBI guidance.
+	MaxGetIDs = 200
 )
 
 // Client is an HTTP client for NCBI E-utilities.
@@ -43,3 +51,13 @@ func NewClient(opts ...Option) *Client {
 func NewClientWithBase(base *ncbi.BaseClient) *Client {
 	return &Client{BaseClient: base}
 }
+
+// doWithIDs sets the comma-joined id parameter and sends the request,
+// using POST form encoding when the ID list exceeds MaxGetIDs.
+func (c *Client) doWithIDs(ctx context.Context, endpoint string, params url.Values, ids []string) ([]byte, error) {
+	params.Set("id", strings.Join(ids, ","))
+	if len(ids) > MaxGetIDs {
+		return c.DoPost(ctx, endpoint, params)
+	}
+	return c.DoGet(ctx, endpoint, params)
+}
@@ -1,12 +1,15 @@
 package eutils
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
 	"fmt"
 	"html"
+	"io"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -18,20 +21,64 @@ var yearRe = regexp.MustCompile(`\d{4}`)
 
 // XML structures for parsing PubMed EFetch responses.
 
-type pubmedArticleSet struct {
-	XMLName  xml.Name        `xml:"PubmedArticleSet"`
-	Articles []pubmedArticle `xml:"PubmedArticle"`
-}
-
 type pubmedArticle struct {
 	Citation   medlineCitation `xml:"MedlineCitation"`
 	PubmedData pubmedData      `xml:"PubmedData"`
 }
 
+// pubmedBookArticle is an NCBI Bookshelf record: a book or book chapter.
+type pubmedBookArticle struct {
+	Document bookDocument `xml:"BookDocument"`
+	BookData pubmedData   `xml:"PubmedBookData"`
+}
+
+type bookDocument struct {
+	PMID             xmlPMID              `xml:"PMID"`
+	ArticleIDList    xmlArticleIDList     `xml:"ArticleIdList"`
+	Book             xmlBook              `xml:"Book"`
+	LocationLabels   []xmlLocationLabel   `xml:"LocationLabel"`
+	ArticleTitle     xmlInnerContent      `xml:"ArticleTitle"`
+	Language         []string             `xml:"Language"`
+	AuthorLists      []xmlAuthorList      `xml:"AuthorList"`
+	PublicationTypes []xmlPublicationType `xml:"PublicationType"`
+	Abstract         xmlAbstract          `xml:"Abstract"`
+	KeywordLists     []xmlKeywordList     `xml:"KeywordList"`
+	GrantList        []xmlGrant           `xml:"GrantList>Grant"`
+}
+
+type xmlBook struct {
+	PublisherName     string           `xml:"Publisher>PublisherName"`
+	PublisherLocation string           `xml:"Publisher>PublisherLocation"`
+	BookTitle         xmlInnerContent  `xml:"BookTitle"`
+	PubDate           xmlPubDate       `xml:"PubDate"`
+	AuthorLists       []xmlAuthorList  `xml:"AuthorList"`
+	Volume            string           `xml:"Volume"`
+	Edition           string           `xml:"Edition"`
+	CollectionTitle   xmlInnerContent  `xml:"CollectionTitle"`
+	ISBNs             []string         `xml:"Isbn"`
+	ELocationIDs      []xmlELocationID `xml:"ELocationID"`
+}
+
+type xmlLocationLabel struct {
+	Type  string `xml:"Type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// xmlDeleteCitation lists PMIDs that NCBI has removed from PubMed.
+type xmlDeleteCitation struct {
+	PMIDs []xmlPMID `xml:"PMID"`
+}
+
 type medlineCitation struct {
-	PMID            xmlPMID            `xml:"PMID"`
-	Article         xmlArticle         `xml:"Article"`
-	MeshHeadingList xmlMeshHeadingList `xml:"MeshHeadingList"`
+	PMID                    xmlPMID                  `xml:"PMID"`
+	Article                 xmlArticle               `xml:"Article"`
+	MeshHeadingList         xmlMeshHeadingList       `xml:"MeshHeadingList"`
+	ChemicalList            []xmlChemical            `xml:"ChemicalList>Chemical"`
+	SupplMeshList           []xmlSupplMeshName       `xml:"SupplMeshList>SupplMeshName"`
+	CommentsCorrectionsList []xmlCommentsCorrections `xml:"CommentsCorrectionsList>CommentsCorrections"`
+	KeywordLists            []xmlKeywordList         `xml:"KeywordList"`
+	CoiStatement            xmlInnerContent          `xml:"CoiStatement"`
+	OtherAbstracts          []xmlOtherAbstract       `xml:"OtherAbstract"`
 }
 
 type xmlPMID struct {
@@ -46,6 +93,66 @@ type xmlArticle struct {
 	Language            []string               `xml:"Language"`
 	PublicationTypeList xmlPublicationTypeList `xml:"PublicationTypeList"`
 	Pagination          xmlPagination          `xml:"Pagination"`
+	ELocationIDs        []xmlELocationID       `xml:"ELocationID"`
+	GrantList           []xmlGrant             `xml:"GrantList>Grant"`
+	DataBankList        []xmlDataBank          `xml:"DataBankList>DataBank"`
+	ArticleDates        []xmlDate              `xml:"ArticleDate"`
+}
+
+type xmlELocationID struct {
+	EIdType string `xml:"EIdType,attr"`
+	ValidYN string `xml:"ValidYN,attr"`
+	Value   string `xml:",chardata"`
+}
+
+type xmlGrant struct {
+	GrantID string `xml:"GrantID"`
+	Acronym string `xml:"Acronym"`
+	Agency  string `xml:"Agency"`
+	Country string `xml:"Country"`
+}
+
+type xmlDataBank struct {
+	Name             string   `xml:"DataBankName"`
+	AccessionNumbers []string `xml:"AccessionNumberList>AccessionNumber"`
+}
+
+// xmlDate covers ArticleDate and PubMedPubDate, which share numeric
+// Year/Month/Day children and differ only in the type attribute name.
+type xmlDate struct {
+	DateType  string `xml:"DateType,attr"`
+	PubStatus string `xml:"PubStatus,attr"`
+	Year      string `xml:"Year"`
+	Month     string `xml:"Month"`
+	Day       string `xml:"Day"`
+}
+
+type xmlChemical struct {
+	RegistryNumber string            `xml:"RegistryNumber"`
+	Substance      xmlDescriptorName `xml:"NameOfSubstance"`
+}
+
+type xmlSupplMeshName struct {
+	Type string `xml:"Type,attr"`
+	UI   string `xml:"UI,attr"`
+	Name string `xml:",chardata"`
+}
+
+type xmlCommentsCorrections struct {
+	RefType   string  `xml:"RefType,attr"`
+	RefSource string  `xml:"RefSource"`
+	PMID      xmlPMID `xml:"PMID"`
+}
+
+type xmlKeywordList struct {
+	Owner    string            `xml:"Owner,attr"`
+	Keywords []xmlInnerContent `xml:"Keyword"`
+}
+
+type xmlOtherAbstract struct {
+	Type          string            `xml:"Type,attr"`
+	Language      string            `xml:"Language,attr"`
+	AbstractTexts []xmlAbstractText `xml:"AbstractText"`
 }
 
 type xmlJournal struct {
@@ -64,6 +171,7 @@ type xmlPubDate struct {
 	Year        string `xml:"Year"`
 	Month       string `xml:"Month"`
 	Day         string `xml:"Day"`
+	Season      string `xml:"Season"`
 	MedlineDate string `xml:"MedlineDate"`
 }
 
@@ -83,6 +191,7 @@ type xmlAbstractText struct {
 }
 
 type xmlAuthorList struct {
+	Type     string      `xml:"Type,attr"` // "authors" or "editors" in book records
 	Complete string      `xml:"CompleteYN,attr"`
 	Authors  []xmlAuthor `xml:"Author"`
 }
@@ -93,9 +202,15 @@ type xmlAuthor struct {
 	ForeName        string               `xml:"ForeName"`
 	Initials        string               `xml:"Initials"`
 	CollectiveName  string               `xml:"CollectiveName"`
+	Identifiers     []xmlIdentifier      `xml:"Identifier"`
 	AffiliationInfo []xmlAffiliationInfo `xml:"AffiliationInfo"`
 }
 
+type xmlIdentifier struct {
+	Source string `xml:"Source,attr"`
+	Value  string `xml:",chardata"`
+}
+
 type xmlAffiliationInfo struct {
 	Affiliation string `xml:"Affiliation"`
 }
@@ -135,6 +250,7 @@ type xmlQualifierName struct {
 }
 
 type pubmedData struct {
+	History       []xmlDate        `xml:"History>PubMedPubDate"`
 	ArticleIDList xmlArticleIDList `xml:"ArticleIdList"`
 }
 
@@ -148,41 +264,159 @@ type xmlArticleID struct {
 }
 
 // Fetch retrieves full article details for the given PMIDs.
+// Large ID lists are sent via POST automatically.
 func (c *Client) Fetch(ctx context.Context, pmids []string) ([]Article, error) {
+	articles, _, err := c.fetchSet(ctx, pmids)
+	return articles, err
+}
+
+// fetchSet is Fetch that also returns the PMIDs NCBI reported as deleted.
+func (c *Client) fetchSet(ctx context.Context, pmids []string) ([]Article, []string, error) {
 	if len(pmids) == 0 {
-		return nil, fmt.Errorf("at least one PMID is required")
+		return nil, nil, fmt.Errorf("at least one PMID is required")
 	}
 
 	params := url.Values{}
 	params.Set("db", "pubmed")
-	params.Set("id", strings.Join(pmids, ","))
 	params.Set("rettype", "xml")
 	params.Set("retmode", "xml")
 
-	body, err := c.DoGet(ctx, "efetch.fcgi", params)
+	body, err := c.doWithIDs(ctx, "efetch.fcgi", params, pmids)
 	if err != nil {
-		return nil, fmt.Errorf("fetch request failed: %w", err)
+		return nil, nil, fmt.Errorf("fetch request failed: %w", err)
 	}
 
-	return parseArticles(body)
+	return parseArticleSet(body)
 }
 
-// parseArticles parses PubMed XML into Article structs.
-func parseArticles(data []byte) ([]Article, error) {
-	var articleSet pubmedArticleSet
-	if err := xml.Unmarshal(data, &articleSet); err != nil {
-		return nil, fmt.Errorf("parsing PubMed XML: %w", err)
+// FetchHistory retrieves articles stored on the NCBI history server by a
+// previous search, without re-sending their IDs. Positions start..start+max-1
+// of the stored set are fetched in pages of DefaultBatchSize.
+func (c *Client) FetchHistory(ctx context.Context, webEnv, queryKey string, start, max int) ([]Article, error) {
+	if webEnv == "" || queryKey == "" {
+		return nil, fmt.Errorf("WebEnv and query key are required")
 	}
+	if max <= 0 {
+		return nil, fmt.Errorf("retmax must be greater than 0")
+	}
+
+	var articles []Article
+	for offset := start; offset < start+max; offset += DefaultBatchSize {
+		n := DefaultBatchSize
+		if remaining := start + max - offset; remaining < n {
+			n = remaining
+		}
 
-	articles := make([]Article, 0, len(articleSet.Articles))
-	for _, pa := range articleSet.Articles {
-		article := convertArticle(pa)
-		articles = append(articles, article)
+		params := url.Values{}
+		params.Set("db", "pubmed")
+		params.Set("WebEnv", webEnv)
+		params.Set("query_key", queryKey)
+		params.Set("retstart", strconv.Itoa(offset))
+		params.Set("retmax", strconv.Itoa(n))
+		params.Set("rettype", "xml")
+		params.Set("retmode", "xml")
+
+		body, err := c.DoGet(ctx, "efetch.fcgi", params)
+		if err != nil {
+			return nil, fmt.Errorf("history fetch request failed: %w", err)
+		}
+		page, err := parseArticles(body)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		articles = append(articles, page...)
 	}
 
 	return articles, nil
 }
 
+// parseArticles parses PubMed XML into Article structs, dropping any
+// DeleteCitation entries.
+func parseArticles(data []byte) ([]Article, error) {
+	articles, _, err := parseArticleSet(data)
+	return articles, err
+}
+
+// parseArticleSet parses PubMed XML into Article structs in document order,
+// covering both journal articles and Bookshelf records, and returns the PMIDs
+// of any DeleteCitation entries separately.
+func parseArticleSet(data []byte) ([]Article, []string, error) {
+	var (
+		articles []Article
+		deleted  []string
+	)
+	err := decodeArticleSet(bytes.NewReader(data), func(a Article) bool {
+		articles = append(articles, a)
+		return true
+	}, func(pmid string) {
+		deleted = append(deleted, pmid)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if articles == nil {
+		articles = []Article{}
+	}
+	return articles, deleted, nil
+}
+
+// decodeArticleSet reads a PubmedArticleSet token by token, decoding and
+// converting one record at a time so memory use does not grow with the size
+// of the response. Deleted PMIDs are passed to onDeleted when it is non-nil.
+// It returns errStopped if fn returns false.
+func decodeArticleSet(r io.Reader, fn func(Article) bool, onDeleted func(string)) error {
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("parsing PubMed XML: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		var a Article
+		switch start.Name.Local {
+		case "PubmedArticle":
+			var pa pubmedArticle
+			if err := dec.DecodeElement(&pa, &start); err != nil {
+				return fmt.Errorf("parsing PubMed XML: %w", err)
+			}
+			a = convertArticle(pa)
+		case "PubmedBookArticle":
+			var pb pubmedBookArticle
+			if err := dec.DecodeElement(&pb, &start); err != nil {
+				return fmt.Errorf("parsing PubMed XML: %w", err)
+			}
+			a = convertBookArticle(pb)
+		case "DeleteCitation":
+			var dc xmlDeleteCitation
+			if err := dec.DecodeElement(&dc, &start); err != nil {
+				return fmt.Errorf("parsing PubMed XML: %w", err)
+			}
+			if onDeleted != nil {
+				for _, p := range dc.PMIDs {
+					onDeleted(strings.TrimSpace(p.Value))
+				}
+			}
+			continue
+		default:
+			continue
+		}
+		if !fn(a) {
+			return errStopped
+		}
+	}
+}
+
 // cleanInnerXML strips XML tags and decodes HTML entities from innerxml content.
 func cleanInnerXML(s string) string {
 	stripped := xmlTagRe.ReplaceAllString(s, "")
@@ -217,6 +451,7 @@ func convertArticle(pa pubmedArticle) Article {
 	} else if pd.MedlineDate != "" {
 		a.Year = extractYearFromMedlineDate(pd.MedlineDate)
 	}
+	a.PubDate = newPubDate(pd)
 
 	// Language
 	if len(xa.Language) > 0 {
@@ -224,29 +459,155 @@ func convertArticle(pa pubmedArticle) Article {
 	}
 
 	// Abstract sections — use cleanInnerXML to handle nested tags
-	for _, at := range xa.Abstract.AbstractTexts {
-		text := cleanInnerXML(at.Inner)
-		a.AbstractSections = append(a.AbstractSections, AbstractSection{
-			Label: at.Label,
-			Text:  text,
+	a.AbstractSections = convertAbstractTexts(xa.Abstract.AbstractTexts)
+	a.Abstract = joinAbstractSections(a.AbstractSections)
+
+	// Authors — support both individual and collective names
+	a.Authors = convertAuthors(xa.AuthorList.Authors)
+
+	// Article IDs (DOI, PMCID)
+	for _, aid := range pa.PubmedData.ArticleIDList.ArticleIDs {
+		switch aid.IDType {
+		case "doi":
+			a.DOI = aid.Value
+		case "pmc":
+			a.PMCID = aid.Value
+		}
+	}
+
+	// Electronic locations; a valid DOI here backs up a missing ArticleId.
+	for _, el := range xa.ELocationIDs {
+		if el.ValidYN == "N" {
+			continue
+		}
+		value := strings.TrimSpace(el.Value)
+		a.ELocationIDs = append(a.ELocationIDs, ELocationID{Type: el.EIdType, Value: value})
+		if el.EIdType == "doi" && a.DOI == "" {
+			a.DOI = value
+		}
+	}
+
+	// MeSH terms
+	for _, mh := range mc.MeshHeadingList.MeshHeadings {
+		term := MeSHTerm{
+			Descriptor:   mh.Descriptor.Name,
+			DescriptorUI: mh.Descriptor.UI,
+			MajorTopic:   mh.Descriptor.MajorTopic == "Y",
+		}
+		for _, q := range mh.Qualifiers {
+			term.Qualifiers = append(term.Qualifiers, MeSHQualifier{
+				Name:       q.Name,
+				UI:         q.UI,
+				MajorTopic: q.MajorTopic == "Y",
+			})
+		}
+		a.MeSHTerms = append(a.MeSHTerms, term)
+	}
+
+	// Publication types
+	for _, pt := range xa.PublicationTypeList.Types {
+		a.PublicationTypes = append(a.PublicationTypes, pt.Name)
+	}
+
+	// Keywords from every owner's list (NOTNLM, NLM, ...)
+	for _, kl := range mc.KeywordLists {
+		for _, kw := range kl.Keywords {
+			if text := cleanInnerXML(kw.Inner); text != "" {
+				a.Keywords = append(a.Keywords, text)
+			}
+		}
+	}
+
+	for _, g := range xa.GrantList {
+		a.Grants = append(a.Grants, Grant{
+			GrantID: strings.TrimSpace(g.GrantID),
+			Acronym: strings.TrimSpace(g.Acronym),
+			Agency:  strings.TrimSpace(g.Agency),
+			Country: strings.TrimSpace(g.Country),
+		})
+	}
+
+	for _, ch := range mc.ChemicalList {
+		c := Chemical{Name: ch.Substance.Name, UI: ch.Substance.UI}
+		// "0" is NLM's placeholder for substances without a registry number.
+		if rn := strings.TrimSpace(ch.RegistryNumber); rn != "0" {
+			c.RegistryNumber = rn
+		}
+		a.Chemicals = append(a.Chemicals, c)
+	}
+
+	for _, sm := range mc.SupplMeshList {
+		a.SupplementaryMeSH = append(a.SupplementaryMeSH, SupplementaryMeSH{
+			Name: sm.Name,
+			UI:   sm.UI,
+			Type: sm.Type,
 		})
 	}
 
-	// Build full abstract text
-	if len(a.AbstractSections) > 0 {
-		var parts []string
-		for _, s := range a.AbstractSections {
-			if s.Label != "" {
-				parts = append(parts, s.Label+": "+s.Text)
-			} else {
-				parts = append(parts, s.Text)
+	for _, cc := range mc.CommentsCorrectionsList {
+		ref := CommentCorrection{
+			RefType:   cc.RefType,
+			RefSource: strings.TrimSpace(cc.RefSource),
+			PMID:      strings.TrimSpace(cc.PMID.Value),
+		}
+		a.CommentsCorrections = append(a.CommentsCorrections, ref)
+
+		switch t := CorrectionType(ref.RefType); t {
+		case CorrectionRetraction, CorrectionErratum, CorrectionExpressionOfConcern, CorrectionUpdate:
+			a.Corrections = append(a.Corrections, Correction{Type: t, Source: ref.RefSource, PMID: ref.PMID})
+			if t == CorrectionRetraction {
+				a.Retracted = true
 			}
 		}
-		a.Abstract = strings.Join(parts, "\n\n")
+	}
+	if hasRetractedType(a.PublicationTypes) {
+		a.Retracted = true
 	}
 
-	// Authors — support both individual and collective names
-	for _, au := range xa.AuthorList.Authors {
+	for _, d := range xa.ArticleDates {
+		if date := isoDate(d.Year, d.Month, d.Day); date != "" {
+			a.ArticleDates = append(a.ArticleDates, ArticleDate{Type: d.DateType, Date: date})
+			if d.DateType == "Electronic" && a.PubDate.Electronic == "" {
+				a.PubDate.Electronic = date
+			}
+		}
+	}
+
+	for _, d := range pa.PubmedData.History {
+		if date := isoDate(d.Year, d.Month, d.Day); date != "" {
+			a.History = append(a.History, HistoryDate{Status: d.PubStatus, Date: date})
+		}
+	}
+
+	a.COIStatement = cleanInnerXML(mc.CoiStatement.Inner)
+
+	for _, db := range xa.DataBankList {
+		a.DataBanks = append(a.DataBanks, DataBank{
+			Name:             strings.TrimSpace(db.Name),
+			AccessionNumbers: db.AccessionNumbers,
+		})
+	}
+
+	for _, oa := range mc.OtherAbstracts {
+		text := joinAbstractSections(convertAbstractTexts(oa.AbstractTexts))
+		if text == "" {
+			continue
+		}
+		a.OtherAbstracts = append(a.OtherAbstracts, OtherAbstract{
+			Type:     oa.Type,
+			Language: oa.Language,
+			Text:     text,
+		})
+	}
+
+	return a
+}
+
+// convertAuthors converts an AuthorList, skipping entries NLM marked
+// invalid and keeping every affiliation and ORCID iD.
+func convertAuthors(list []xmlAuthor) []Author {
+	var authors []Author
+	for _, au := range list {
 		if au.ValidYN == "N" {
 			continue
 		}
@@ -258,39 +619,199 @@ func convertArticle(pa pubmedArticle) Article {
 			author.ForeName = au.ForeName
 			author.Initials = au.Initials
 		}
-		if len(au.AffiliationInfo) > 0 {
-			author.Affiliation = au.AffiliationInfo[0].Affiliation
+		for _, ai := range au.AffiliationInfo {
+			if aff := strings.TrimSpace(ai.Affiliation); aff != "" {
+				author.Affiliations = append(author.Affiliations, aff)
+			}
+		}
+		if len(author.Affiliations) > 0 {
+			author.Affiliation = author.Affiliations[0]
+		}
+		for _, id := range au.Identifiers {
+			if strings.EqualFold(id.Source, "ORCID") {
+				author.ORCID = normalizeORCID(id.Value)
+			}
 		}
-		a.Authors = append(a.Authors, author)
+		authors = append(authors, author)
 	}
+	return authors
+}
 
-	// Article IDs (DOI, PMCID)
-	for _, aid := range pa.PubmedData.ArticleIDList.ArticleIDs {
+// convertBookArticle converts a Bookshelf record. Chapters carry their own
+// ArticleTitle; whole-book records use the book title as the article title.
+func convertBookArticle(pb pubmedBookArticle) Article {
+	doc := pb.Document
+	book := doc.Book
+
+	info := &BookInfo{
+		Type:              BookTypeBook,
+		Title:             cleanInnerXML(book.BookTitle.Inner),
+		Publisher:         strings.TrimSpace(book.PublisherName),
+		PublisherLocation: strings.TrimSpace(book.PublisherLocation),
+		Edition:           strings.TrimSpace(book.Edition),
+		Volume:            strings.TrimSpace(book.Volume),
+		Collection:        cleanInnerXML(book.CollectionTitle.Inner),
+	}
+	for _, isbn := range book.ISBNs {
+		if isbn = strings.TrimSpace(isbn); isbn != "" {
+			info.ISBNs = append(info.ISBNs, isbn)
+		}
+	}
+	// PubMed leaves Type empty for authors, so only "editors" lists are
+	// editors; book-level authors stand in for missing document authors.
+	var bookAuthors []Author
+	for _, al := range book.AuthorLists {
+		switch al.Type {
+		case "editors":
+			info.Editors = append(info.Editors, convertAuthors(al.Authors)...)
+		case "", "authors":
+			bookAuthors = append(bookAuthors, convertAuthors(al.Authors)...)
+		}
+	}
+	for _, ll := range doc.LocationLabels {
+		if ll.Type == "chapter" {
+			info.Chapter = strings.TrimSpace(ll.Value)
+			break
+		}
+	}
+
+	a := Article{
+		PMID:    strings.TrimSpace(doc.PMID.Value),
+		Title:   cleanInnerXML(doc.ArticleTitle.Inner),
+		Year:    book.PubDate.Year,
+		Month:   book.PubDate.Month,
+		PubDate: newPubDate(book.PubDate),
+		Book:    info,
+	}
+	if a.Title != "" {
+		info.Type = BookTypeChapter
+	} else {
+		a.Title = info.Title
+	}
+	if a.Year == "" && book.PubDate.MedlineDate != "" {
+		a.Year = extractYearFromMedlineDate(book.PubDate.MedlineDate)
+	}
+	if len(doc.Language) > 0 {
+		a.Language = doc.Language[0]
+	}
+
+	a.AbstractSections = convertAbstractTexts(doc.Abstract.AbstractTexts)
+	a.Abstract = joinAbstractSections(a.AbstractSections)
+
+	for _, al := range doc.AuthorLists {
+		if al.Type == "" || al.Type == "authors" {
+			a.Authors = append(a.Authors, convertAuthors(al.Authors)...)
+		}
+	}
+	if len(a.Authors) == 0 {
+		a.Authors = bookAuthors
+	}
+
+	for _, pt := range doc.PublicationTypes {
+		a.PublicationTypes = append(a.PublicationTypes, pt.Name)
+	}
+	for _, kl := range doc.KeywordLists {
+		for _, kw := range kl.Keywords {
+			if text := cleanInnerXML(kw.Inner); text != "" {
+				a.Keywords = append(a.Keywords, text)
+			}
+		}
+	}
+	for _, g := range doc.GrantList {
+		a.Grants = append(a.Grants, Grant{GrantID: g.GrantID, Acronym: g.Acronym, Agency: g.Agency, Country: g.Country})
+	}
+
+	ids := append(append([]xmlArticleID(nil), doc.ArticleIDList.ArticleIDs...), pb.BookData.ArticleIDList.ArticleIDs...)
+	for _, aid := range ids {
+		value := strings.TrimSpace(aid.Value)
 		switch aid.IDType {
+		case "bookaccession":
+			info.Accession = value
 		case "doi":
-			a.DOI = aid.Value
+			a.DOI = value
 		case "pmc":
-			a.PMCID = aid.Value
+			a.PMCID = value
 		}
 	}
-
-	// MeSH terms
-	for _, mh := range mc.MeshHeadingList.MeshHeadings {
-		term := MeSHTerm{
-			Descriptor:   mh.Descriptor.Name,
-			DescriptorUI: mh.Descriptor.UI,
-			MajorTopic:   mh.Descriptor.MajorTopic == "Y",
+	for _, el := range book.ELocationIDs {
+		if el.ValidYN == "N" {
+			continue
 		}
-		for _, q := range mh.Qualifiers {
-			term.Qualifiers = append(term.Qualifiers, q.Name)
+		value := strings.TrimSpace(el.Value)
+		a.ELocationIDs = append(a.ELocationIDs, ELocationID{Type: el.EIdType, Value: value})
+		if el.EIdType == "doi" && a.DOI == "" {
+			a.DOI = value
 		}
-		a.MeSHTerms = append(a.MeSHTerms, term)
 	}
 
-	// Publication types
-	for _, pt := range xa.PublicationTypeList.Types {
-		a.PublicationTypes = append(a.PublicationTypes, pt.Name)
+	for _, d := range pb.BookData.History {
+		if date := isoDate(d.Year, d.Month, d.Day); date != "" {
+			a.History = append(a.History, HistoryDate{Status: d.PubStatus, Date: date})
+		}
 	}
 
 	return a
 }
+
+// hasRetractedType reports whether NLM has tagged the record as a
+// "Retracted Publication".
+func hasRetractedType(pubTypes []string) bool {
+	for _, pt := range pubTypes {
+		if strings.EqualFold(pt, "Retracted Publication") {
+			return true
+		}
+	}
+	return false
+}
+
+// convertAbstractTexts cleans AbstractText elements into labeled sections.
+func convertAbstractTexts(texts []xmlAbstractText) []AbstractSection {
+	var sections []AbstractSection
+	for _, at := range texts {
+		sections = append(sections, AbstractSection{
+			Label: at.Label,
+			Text:  cleanInnerXML(at.Inner),
+		})
+	}
+	return sections
+}
+
+// joinAbstractSections builds full abstract text, prefixing labeled sections.
+func joinAbstractSections(sections []AbstractSection) string {
+	var parts []string
+	for _, s := range sections {
+		if s.Label != "" {
+			parts = append(parts, s.Label+": "+s.Text)
+		} else {
+			parts = append(parts, s.Text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// isoDate formats numeric year/month/day parts as YYYY-MM-DD, or as YYYY-MM
+// or YYYY when the finer parts are missing. Invalid parts yield "".
+func isoDate(year, month, day string) string {
+	year = strings.TrimSpace(year)
+	if len(year) != 4 {
+		return ""
+	}
+	m, err := strconv.Atoi(strings.TrimSpace(month))
+	if err != nil || m < 1 || m > 12 {
+		return year
+	}
+	d, err := strconv.Atoi(strings.TrimSpace(day))
+	if err != nil || d < 1 || d > 31 {
+		return fmt.Sprintf("%s-%02d", year, m)
+	}
+	return fmt.Sprintf("%s-%02d-%02d", year, m, d)
+}
+
+// normalizeORCID strips the https://orcid.org/ prefix NLM sometimes includes.
+func normalizeORCID(id string) string {
+	id = strings.TrimSpace(id)
+	for _, prefix := range []string{"https://orcid.org/", "http://orcid.org/", "orcid.org/"} {
+		id = strings.TrimPrefix(id, prefix)
+	}
+	return id
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -114,6 +116,8 @@ func TestFetch_StructuredAbstract(t *testing.T) {
 	}
 	if len(a.MeSHTerms[0].Qualifiers) != 2 {
 		t.Errorf("expected 2 qualifiers for first MeSH term, got %d", len(a.MeSHTerms[0].Qualifiers))
+	} else if q := a.MeSHTerms[0].Qualifiers[0]; q.Name != "physiopathology" || q.UI != "Q000503" || q.MajorTopic {
+		t.Errorf("unexpected first qualifier %+v", q)
 	}
 
 	// Publication types
@@ -235,6 +239,10 @@ func TestFetch_MedlineDate(t *testing.T) {
 	if a.Year != "2020" {
 		t.Errorf("expected year '2020' from MedlineDate, got %q", a.Year)
 	}
+	want := PubDate{Year: 2020, Month: 1, MedlineDate: "2020 Jan-Feb"}
+	if a.PubDate != want {
+		t.Errorf("expected PubDate %+v, got %+v", want, a.PubDate)
+	}
 }
 
 func TestFetch_CollectiveAuthor(t *testing.T) {
@@ -339,6 +347,248 @@ func TestFetch_NestedTags(t *testing.T) {
 	}
 }
 
+func TestFetch_FullRecord(t *testing.T) {
+	articles, err := parseArticles(loadTestdata(t, "efetch_full_record.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(articles) != 1 {
+		t.Fatalf("expected 1 article, got %d", len(articles))
+	}
+	a := articles[0]
+
+	if len(a.MeSHTerms) != 1 || !a.MeSHTerms[0].Major() {
+		t.Fatalf("expected one major MeSH heading, got %+v", a.MeSHTerms)
+	}
+	if names := a.MeSHTerms[0].QualifierNames(); len(names) != 1 || names[0] != "drug therapy*" {
+		t.Errorf("expected major drug therapy qualifier, got %v", names)
+	}
+
+	if want := (PubDate{Year: 2023, Month: 6, Day: 5, Electronic: "2023-06-05"}); a.PubDate != want {
+		t.Errorf("expected PubDate %+v, got %+v", want, a.PubDate)
+	}
+
+	// DOI comes from the valid ELocationID when ArticleIdList lacks one.
+	if a.DOI != "10.1186/s13229-023-00555-1" {
+		t.Errorf("expected DOI from ELocationID, got %q", a.DOI)
+	}
+	if len(a.ELocationIDs) != 2 || a.ELocationIDs[0].Type != "pii" {
+		t.Errorf("expected 2 valid ELocationIDs, got %+v", a.ELocationIDs)
+	}
+
+	first := a.Authors[0]
+	if first.ORCID != "0000-0002-1825-0097" {
+		t.Errorf("expected normalized ORCID, got %q", first.ORCID)
+	}
+	if len(first.Affiliations) != 2 || first.Affiliation != first.Affiliations[0] {
+		t.Errorf("expected both affiliations with the first kept in Affiliation, got %+v", first)
+	}
+	if a.Authors[1].ORCID != "0000-0001-5678-1234" {
+		t.Errorf("unexpected second ORCID %q", a.Authors[1].ORCID)
+	}
+
+	if got := strings.Join(a.Keywords, "|"); got != "EEG|FMR1|Gamma oscillations" {
+		t.Errorf("unexpected keywords %q", got)
+	}
+	if len(a.Grants) != 2 || a.Grants[0].GrantID != "R01 HD093771" || a.Grants[0].Agency != "NICHD NIH HHS" || a.Grants[0].Country != "United States" {
+		t.Errorf("unexpected grants: %+v", a.Grants)
+	}
+	if len(a.Chemicals) != 2 || a.Chemicals[0].RegistryNumber != "9100L32L2N" || a.Chemicals[1].RegistryNumber != "" {
+		t.Errorf("unexpected chemicals: %+v", a.Chemicals)
+	}
+	if len(a.SupplementaryMeSH) != 1 || a.SupplementaryMeSH[0].UI != "C536609" || a.SupplementaryMeSH[0].Type != "Disease" {
+		t.Errorf("unexpected supplementary MeSH: %+v", a.SupplementaryMeSH)
+	}
+	if len(a.CommentsCorrections) != 2 || a.CommentsCorrections[0].RefType != "ErratumIn" || a.CommentsCorrections[0].PMID != "37000999" {
+		t.Errorf("unexpected comments/corrections: %+v", a.CommentsCorrections)
+	}
+	if len(a.Corrections) != 1 || a.Corrections[0].Type != CorrectionErratum || a.Corrections[0].PMID != "37000999" {
+		t.Errorf("expected only the erratum in corrections, got %+v", a.Corrections)
+	}
+	if a.Retracted {
+		t.Error("erratum alone should not mark the article retracted")
+	}
+	if len(a.ArticleDates) != 1 || a.ArticleDates[0].Type != "Electronic" || a.ArticleDates[0].Date != "2023-06-05" {
+		t.Errorf("unexpected article dates: %+v", a.ArticleDates)
+	}
+	if len(a.History) != 3 || a.History[0].Status != "received" || a.History[0].Date != "2023-01-09" {
+		t.Errorf("unexpected history: %+v", a.History)
+	}
+	if !strings.Contains(a.COIStatement, "Zynerba") || strings.Contains(a.COIStatement, "<b>") {
+		t.Errorf("unexpected COI statement %q", a.COIStatement)
+	}
+	if len(a.DataBanks) != 2 || a.DataBanks[0].Name != "ClinicalTrials.gov" || len(a.DataBanks[1].AccessionNumbers) != 2 {
+		t.Errorf("unexpected data banks: %+v", a.DataBanks)
+	}
+	if len(a.OtherAbstracts) != 1 || a.OtherAbstracts[0].Type != "plain-language-summary" || !strings.Contains(a.OtherAbstracts[0].Text, "metformin") {
+		t.Errorf("unexpected other abstracts: %+v", a.OtherAbstracts)
+	}
+	// CopyrightInformation must not leak into the abstract.
+	if strings.Contains(a.Abstract, "Author(s)") {
+		t.Errorf("abstract should not include copyright, got %q", a.Abstract)
+	}
+}
+
+func TestFetch_RetractedArticle(t *testing.T) {
+	articles, err := parseArticles(loadTestdata(t, "efetch_retracted.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(articles) != 1 {
+		t.Fatalf("expected 1 article, got %d", len(articles))
+	}
+
+	a := articles[0]
+	if !a.Retracted {
+		t.Error("expected article to be flagged as retracted")
+	}
+	if len(a.CommentsCorrections) != 3 {
+		t.Errorf("expected 3 comments/corrections, got %d", len(a.CommentsCorrections))
+	}
+
+	// CommentIn is not a correction notice.
+	want := []Correction{
+		{Type: CorrectionExpressionOfConcern, Source: "Transl Psychiatry. 2020 Feb 3;10(1):40", PMID: "32011111"},
+		{Type: CorrectionRetraction, Source: "Transl Psychiatry. 2021 Mar 1;11(1):150", PMID: "33622222"},
+	}
+	if len(a.Corrections) != len(want) {
+		t.Fatalf("expected %d corrections, got %+v", len(want), a.Corrections)
+	}
+	for i, c := range want {
+		if a.Corrections[i] != c {
+			t.Errorf("correction %d: expected %+v, got %+v", i, c, a.Corrections[i])
+		}
+	}
+}
+
+func TestParseArticleSet_BookAuthorsWithoutType(t *testing.T) {
+	xml := `<PubmedArticleSet><PubmedBookArticle><BookDocument>
+		<PMID Version="1">20821847</PMID>
+		<Book>
+			<BookTitle book="mono">Methods in Neurodevelopment</BookTitle>
+			<PubDate><Year>2010</Year></PubDate>
+			<AuthorList>
+				<Author><LastName>Smith</LastName><ForeName>Jane</ForeName><Initials>J</Initials></Author>
+			</AuthorList>
+			<AuthorList Type="editors">
+				<Author><LastName>Jones</LastName><ForeName>Ann</ForeName><Initials>A</Initials></Author>
+			</AuthorList>
+		</Book>
+	</BookDocument></PubmedBookArticle></PubmedArticleSet>`
+
+	articles, _, err := parseArticleSet([]byte(xml))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(articles) != 1 || articles[0].Book == nil {
+		t.Fatalf("expected one book record, got %+v", articles)
+	}
+	bk := articles[0]
+	if len(bk.Book.Editors) != 1 || bk.Book.Editors[0].LastName != "Jones" {
+		t.Errorf("expected only the typed list as editors, got %+v", bk.Book.Editors)
+	}
+	if len(bk.Authors) != 1 || bk.Authors[0].LastName != "Smith" {
+		t.Errorf("expected untyped book list as authors, got %+v", bk.Authors)
+	}
+}
+
+func TestParseArticleSet_BooksAndDeletions(t *testing.T) {
+	articles, deleted, err := parseArticleSet(loadTestdata(t, "efetch_book.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Books and journal articles keep document order.
+	if len(articles) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(articles))
+	}
+	if articles[0].PMID != "20301558" || articles[1].PMID != "35999876" || articles[2].PMID != "21413016" {
+		t.Errorf("unexpected order: %s, %s, %s", articles[0].PMID, articles[1].PMID, articles[2].PMID)
+	}
+	if articles[1].Book != nil {
+		t.Error("journal article should have no book info")
+	}
+	if len(deleted) != 2 || deleted[0] != "30000001" || deleted[1] != "30000002" {
+		t.Errorf("unexpected deleted PMIDs: %v", deleted)
+	}
+
+	ch := articles[0]
+	if ch.Book == nil {
+		t.Fatal("expected book info for chapter")
+	}
+	if ch.Book.Type != BookTypeChapter || ch.Title != "FMR1 Disorders" || ch.Book.Chapter != "FMR1 Disorders" {
+		t.Errorf("unexpected chapter: type=%q title=%q chapter=%q", ch.Book.Type, ch.Title, ch.Book.Chapter)
+	}
+	if ch.Book.Title != "GeneReviews®" {
+		t.Errorf("unexpected book title %q", ch.Book.Title)
+	}
+	if ch.Book.Publisher != "University of Washington, Seattle" || ch.Book.PublisherLocation != "Seattle (WA)" {
+		t.Errorf("unexpected publisher %q / %q", ch.Book.Publisher, ch.Book.PublisherLocation)
+	}
+	if len(ch.Book.Editors) != 2 || ch.Book.Editors[0].LastName != "Adam" {
+		t.Errorf("unexpected editors: %+v", ch.Book.Editors)
+	}
+	if len(ch.Authors) != 2 || ch.Authors[0].LastName != "Hunter" || ch.Authors[0].Affiliation != "RTI International" {
+		t.Errorf("unexpected authors: %+v", ch.Authors)
+	}
+	if ch.Book.Accession != "NBK1384" || ch.Year != "1993" || ch.Language != "eng" {
+		t.Errorf("unexpected accession/year/language: %q %q %q", ch.Book.Accession, ch.Year, ch.Language)
+	}
+	if len(ch.AbstractSections) != 2 || !strings.Contains(ch.Abstract, "molecular genetic testing of FMR1") {
+		t.Errorf("unexpected abstract: %q", ch.Abstract)
+	}
+	if len(ch.PublicationTypes) != 1 || ch.PublicationTypes[0] != "Review" {
+		t.Errorf("unexpected publication types: %v", ch.PublicationTypes)
+	}
+
+	bk := articles[2]
+	if bk.Book == nil || bk.Book.Type != BookTypeBook {
+		t.Fatalf("expected whole-book record, got %+v", bk.Book)
+	}
+	if bk.Title != "Dietary Reference Intakes for Calcium and Vitamin D" || bk.Title != bk.Book.Title {
+		t.Errorf("whole book should use the book title, got %q", bk.Title)
+	}
+	if bk.DOI != "10.17226/13050" || len(bk.Book.ISBNs) != 2 {
+		t.Errorf("unexpected DOI/ISBNs: %q %v", bk.DOI, bk.Book.ISBNs)
+	}
+	if !strings.HasPrefix(bk.Book.Collection, "The National Academies Collection") {
+		t.Errorf("unexpected collection %q", bk.Book.Collection)
+	}
+	if len(bk.Authors) != 1 || bk.Authors[0].CollectiveName == "" {
+		t.Errorf("expected collective author, got %+v", bk.Authors)
+	}
+}
+
+func TestCorrectionType_Label(t *testing.T) {
+	tests := map[CorrectionType]string{
+		CorrectionRetraction:          "Retraction",
+		CorrectionErratum:             "Erratum",
+		CorrectionExpressionOfConcern: "Expression of concern",
+		CorrectionUpdate:              "Update",
+		"RepublishedIn":               "RepublishedIn",
+	}
+	for ct, want := range tests {
+		if got := ct.Label(); got != want {
+			t.Errorf("%s.Label() = %q, want %q", ct, got, want)
+		}
+	}
+}
+
+func TestISODate(t *testing.T) {
+	tests := []struct{ y, m, d, want string }{
+		{"2023", "6", "5", "2023-06-05"},
+		{"2023", "12", "", "2023-12"},
+		{"2023", "", "", "2023"},
+		{"", "1", "1", ""},
+		{"2023", "Jun", "5", "2023"},
+	}
+	for _, tt := range tests {
+		if got := isoDate(tt.y, tt.m, tt.d); got != tt.want {
+			t.Errorf("isoDate(%q, %q, %q) = %q, want %q", tt.y, tt.m, tt.d, got, tt.want)
+		}
+	}
+}
+
 func containsSubstring(s, substr string) bool {
 	for i := 0; i <= len(s)-len(substr); i++ {
 		if s[i:i+len(substr)] == substr {
@@ -373,3 +623,95 @@ func TestFetch_ServerError(t *testing.T) {
 		t.Error("expected error for server error, got nil")
 	}
 }
+
+func TestFetch_LargeIDListUsesPOST(t *testing.T) {
+	fixture := loadTestdata(t, "efetch_simple.xml")
+
+	pmids := make([]string, MaxGetIDs+1)
+	for i := range pmids {
+		pmids[i] = strconv.Itoa(30000000 + i)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST for %d IDs, got %s", len(pmids), r.Method)
+		}
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected empty query string for POST, got %q", r.URL.RawQuery)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parsing form: %v", err)
+		}
+		if got := strings.Split(r.PostForm.Get("id"), ","); len(got) != len(pmids) {
+			t.Errorf("expected %d IDs in form body, got %d", len(pmids), len(got))
+		}
+		if got := r.PostForm.Get("db"); got != "pubmed" {
+			t.Errorf("expected db=pubmed, got %q", got)
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	if _, err := c.Fetch(context.Background(), pmids); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFetch_SmallIDListUsesGET(t *testing.T) {
+	fixture := loadTestdata(t, "efetch_simple.xml")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	if _, err := c.Fetch(context.Background(), []string{"35999876", "35999877"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFetchHistory_UsesWebEnv(t *testing.T) {
+	fixture := loadTestdata(t, "efetch_simple.xml")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("id") != "" {
+			t.Errorf("expected no id param for history fetch, got %q", q.Get("id"))
+		}
+		if got := q.Get("WebEnv"); got != "MCID_123" {
+			t.Errorf("expected WebEnv=MCID_123, got %q", got)
+		}
+		if got := q.Get("query_key"); got != "1" {
+			t.Errorf("expected query_key=1, got %q", got)
+		}
+		if got := q.Get("retstart"); got != "20" {
+			t.Errorf("expected retstart=20, got %q", got)
+		}
+		if got := q.Get("retmax"); got != "5" {
+			t.Errorf("expected retmax=5, got %q", got)
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	articles, err := c.FetchHistory(context.Background(), "MCID_123", "1", 20, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(articles) != 1 || articles[0].PMID != "35999876" {
+		t.Errorf("unexpected articles: %+v", articles)
+	}
+}
+
+func TestFetchHistory_RequiresHandle(t *testing.T) {
+	c := NewClient(WithAPIKey("test"))
+	if _, err := c.FetchHistory(context.Background(), "", "1", 0, 10); err == nil {
+		t.Error("expected error for missing WebEnv")
+	}
+}
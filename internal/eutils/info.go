@@ -0,0 +1,131 @@
+package eutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// EInfo JSON response structures.
+type einfoResponse struct {
+	Result struct {
+		DBInfo json.RawMessage `json:"dbinfo"`
+	} `json:"einforesult"`
+}
+
+type einfoDB struct {
+	DBName      string       `json:"dbname"`
+	MenuName    string       `json:"menuname"`
+	Description string       `json:"description"`
+	DBBuild     string       `json:"dbbuild"`
+	Count       string       `json:"count"`
+	LastUpdate  string       `json:"lastupdate"`
+	FieldList   []einfoField `json:"fieldlist"`
+	LinkList    []einfoLink  `json:"linklist"`
+}
+
+type einfoField struct {
+	Name        string `json:"name"`
+	FullName    string `json:"fullname"`
+	Description string `json:"description"`
+	TermCount   string `json:"termcount"`
+	IsDate      string `json:"isdate"`
+	IsNumerical string `json:"isnumerical"`
+	Hierarchy   string `json:"hierarchy"`
+	IsHidden    string `json:"ishidden"`
+}
+
+type einfoLink struct {
+	Name        string `json:"name"`
+	Menu        string `json:"menu"`
+	Description string `json:"description"`
+	DbTo        string `json:"dbto"`
+}
+
+// Info retrieves EInfo metadata for db: the searchable fields (the tags used
+// as [tiab], [dp], ...) and the ELink link names available from it.
+func (c *Client) Info(ctx context.Context, db string) (*DBInfo, error) {
+	if db == "" {
+		return nil, fmt.Errorf("database name cannot be empty")
+	}
+
+	params := url.Values{}
+	params.Set("db", db)
+	params.Set("retmode", "json")
+	params.Set("version", "2.0")
+
+	body, err := c.DoGet(ctx, "einfo.fcgi", params)
+	if err != nil {
+		return nil, fmt.Errorf("info request failed: %w", err)
+	}
+
+	return parseInfo(body)
+}
+
+// parseInfo decodes an EInfo JSON payload. dbinfo is an array in current
+// responses and a bare object in older ones; both are accepted.
+func parseInfo(data []byte) (*DBInfo, error) {
+	var resp einfoResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing info response: %w", err)
+	}
+
+	var dbs []einfoDB
+	if err := json.Unmarshal(resp.Result.DBInfo, &dbs); err != nil {
+		var single einfoDB
+		if err := json.Unmarshal(resp.Result.DBInfo, &single); err != nil {
+			return nil, fmt.Errorf("parsing info dbinfo: %w", err)
+		}
+		dbs = []einfoDB{single}
+	}
+	if len(dbs) == 0 || dbs[0].DBName == "" {
+		return nil, fmt.Errorf("info response contains no database description")
+	}
+
+	db := dbs[0]
+	info := &DBInfo{
+		Name:        db.DBName,
+		MenuName:    db.MenuName,
+		Description: db.Description,
+		Build:       db.DBBuild,
+		Count:       atoiOrZero(db.Count),
+		LastUpdate:  db.LastUpdate,
+	}
+	for _, f := range db.FieldList {
+		info.Fields = append(info.Fields, FieldInfo{
+			Name:        f.Name,
+			FullName:    f.FullName,
+			Description: f.Description,
+			TermCount:   atoiOrZero(f.TermCount),
+			IsDate:      f.IsDate == "Y",
+			IsNumerical: f.IsNumerical == "Y",
+			Hierarchy:   f.Hierarchy == "Y",
+			Hidden:      f.IsHidden == "Y",
+		})
+	}
+	for _, l := range db.LinkList {
+		info.Links = append(info.Links, LinkInfo{
+			Name:        l.Name,
+			Menu:        l.Menu,
+			Description: l.Description,
+			DbTo:        l.DbTo,
+		})
+	}
+	return info, nil
+}
+
+// Tag returns the field's search tag as used in queries, e.g. "[tiab]".
+func (f FieldInfo) Tag() string {
+	return "[" + strings.ToLower(f.Name) + "]"
+}
+
+func atoiOrZero(s string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0
+	}
+	return n
+}
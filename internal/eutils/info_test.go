@@ -0,0 +1,79 @@
+package eutils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInfo_ParsesFieldsAndLinks(t *testing.T) {
+	fixture := loadTestdata(t, "einfo_pubmed.json")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/einfo.fcgi" {
+			t.Errorf("expected einfo.fcgi, got %s", r.URL.Path)
+		}
+		if got := q.Get("db"); got != "pubmed" {
+			t.Errorf("expected db=pubmed, got %q", got)
+		}
+		if got := q.Get("version"); got != "2.0" {
+			t.Errorf("expected version=2.0, got %q", got)
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	info, err := c.Info(context.Background(), "pubmed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.Name != "pubmed" || info.Count != 38512345 {
+		t.Errorf("unexpected db info: %s / %d", info.Name, info.Count)
+	}
+	if len(info.Fields) != 5 {
+		t.Fatalf("expected 5 fields, got %d", len(info.Fields))
+	}
+
+	tiab := info.Fields[1]
+	if tiab.Tag() != "[tiab]" || tiab.FullName != "Title/Abstract" || tiab.TermCount != 123456789 {
+		t.Errorf("unexpected TIAB field: %+v", tiab)
+	}
+	if !info.Fields[2].IsDate {
+		t.Error("expected PDAT to be a date field")
+	}
+	if !info.Fields[3].Hierarchy {
+		t.Error("expected MESH to be hierarchical")
+	}
+	if !info.Fields[4].Hidden {
+		t.Error("expected UID to be hidden")
+	}
+
+	if len(info.Links) != 3 {
+		t.Fatalf("expected 3 links, got %d", len(info.Links))
+	}
+	if info.Links[0].Name != "pubmed_gene" || info.Links[0].DbTo != "gene" {
+		t.Errorf("unexpected first link: %+v", info.Links[0])
+	}
+}
+
+func TestParseInfo_SingleObject(t *testing.T) {
+	data := []byte(`{"einforesult":{"dbinfo":{"dbname":"gene","count":"12","fieldlist":[{"name":"SYM","termcount":"5"}]}}}`)
+	info, err := parseInfo(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Name != "gene" || len(info.Fields) != 1 || info.Fields[0].TermCount != 5 {
+		t.Errorf("unexpected info: %+v", info)
+	}
+}
+
+func TestInfo_EmptyDB(t *testing.T) {
+	c := NewClient(WithAPIKey("test"))
+	if _, err := c.Info(context.Background(), ""); err == nil {
+		t.Error("expected error for empty database name")
+	}
+}
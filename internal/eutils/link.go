@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"slices"
 	"strconv"
 )
 
@@ -92,22 +93,143 @@ func (c *Client) Related(ctx context.Context, pmid string) (*LinkResult, error)
 	return c.link(ctx, pmid, linkRelated, true)
 }
 
+// CitedByBatch returns the citing papers of each PMID using a single ELink
+// request, one LinkResult per PMID in request order.
+func (c *Client) CitedByBatch(ctx context.Context, pmids []string) ([]LinkResult, error) {
+	return c.linkBatch(ctx, pmids, linkCitedIn, false)
+}
+
+// ReferencesBatch returns the references of each PMID using a single ELink
+// request, one LinkResult per PMID in request order.
+func (c *Client) ReferencesBatch(ctx context.Context, pmids []string) ([]LinkResult, error) {
+	return c.linkBatch(ctx, pmids, linkRefs, false)
+}
+
+// RelatedBatch returns scored similar articles for each PMID using a single
+// ELink request, one LinkResult per PMID in request order.
+func (c *Client) RelatedBatch(ctx context.Context, pmids []string) ([]LinkResult, error) {
+	return c.linkBatch(ctx, pmids, linkRelated, true)
+}
+
+// AggregateLinks unions the links of results and counts how many sources
+// link to each target. Self-links (a source among its own related
+// articles) are skipped. Targets are ordered by count, highest first, then
+// by first appearance.
+func AggregateLinks(results []LinkResult) []AggregatedLink {
+	index := make(map[string]int)
+	var links []AggregatedLink
+	for _, r := range results {
+		for _, l := range r.Links {
+			if l.ID == r.SourceID {
+				continue
+			}
+			i, ok := index[l.ID]
+			if !ok {
+				i = len(links)
+				index[l.ID] = i
+				links = append(links, AggregatedLink{ID: l.ID})
+			}
+			if slices.Contains(links[i].Sources, r.SourceID) {
+				continue
+			}
+			links[i].Count++
+			links[i].Sources = append(links[i].Sources, r.SourceID)
+		}
+	}
+	slices.SortStableFunc(links, func(a, b AggregatedLink) int {
+		return b.Count - a.Count
+	})
+	if links == nil {
+		links = []AggregatedLink{}
+	}
+	return links
+}
+
+// Link runs ELink from dbFrom to dbTo for each of ids and returns one
+// LinkSet per source ID, in request order. linkName restricts the results
+// to a single link (e.g. "pubmed_gene"); when empty, every link NCBI has
+// between the two databases is returned as its own LinkSetDB.
+func (c *Client) Link(ctx context.Context, ids []string, dbFrom, dbTo, linkName string) ([]LinkSet, error) {
+	return c.linkSets(ctx, ids, dbFrom, dbTo, linkName, false)
+}
+
 func (c *Client) link(ctx context.Context, pmid, linkName string, withScores bool) (*LinkResult, error) {
 	if pmid == "" {
 		return nil, fmt.Errorf("PMID cannot be empty")
 	}
 
+	sets, err := c.linkSets(ctx, []string{pmid}, "pubmed", "pubmed", linkName, withScores)
+	if err != nil {
+		return nil, err
+	}
+	result := linkResult(sets[0])
+	return &result, nil
+}
+
+func (c *Client) linkBatch(ctx context.Context, pmids []string, linkName string, withScores bool) ([]LinkResult, error) {
+	for _, pmid := range pmids {
+		if pmid == "" {
+			return nil, fmt.Errorf("PMID cannot be empty")
+		}
+	}
+
+	sets, err := c.linkSets(ctx, pmids, "pubmed", "pubmed", linkName, withScores)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]LinkResult, len(sets))
+	for i, set := range sets {
+		results[i] = linkResult(set)
+	}
+	return results, nil
+}
+
+// linkResult flattens a link set into a LinkResult. Links is a non-nil
+// empty slice when there are none, for JSON serialization.
+func linkResult(set LinkSet) LinkResult {
+	result := LinkResult{SourceID: set.SourceID, Links: []LinkItem{}}
+	for _, db := range set.LinkSetDBs {
+		result.Links = append(result.Links, db.Links...)
+	}
+	return result
+}
+
+func (c *Client) linkSets(ctx context.Context, ids []string, dbFrom, dbTo, linkName string, withScores bool) ([]LinkSet, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one ID is required")
+	}
+	if dbFrom == "" || dbTo == "" {
+		return nil, fmt.Errorf("source and target databases are required")
+	}
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("ID cannot be empty")
+		}
+	}
+
 	params := url.Values{}
-	params.Set("dbfrom", "pubmed")
-	params.Set("db", "pubmed")
-	params.Set("id", pmid)
-	params.Set("linkname", linkName)
+	params.Set("dbfrom", dbFrom)
+	params.Set("db", dbTo)
+	if linkName != "" {
+		params.Set("linkname", linkName)
+	}
 	params.Set("retmode", "json")
 	if withScores {
 		params.Set("cmd", "neighbor_score")
 	}
+	// A repeated id parameter, unlike a comma-joined list, makes ELink
+	// return a separate linkset for each source ID instead of their union.
+	params["id"] = append([]string(nil), ids...)
 
-	body, err := c.DoGet(ctx, "elink.fcgi", params)
+	var (
+		body []byte
+		err  error
+	)
+	if len(ids) > MaxGetIDs {
+		body, err = c.DoPost(ctx, "elink.fcgi", params)
+	} else {
+		body, err = c.DoGet(ctx, "elink.fcgi", params)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("link request failed: %w", err)
 	}
@@ -117,15 +239,23 @@ func (c *Client) link(ctx context.Context, pmid, linkName string, withScores boo
 		return nil, fmt.Errorf("parsing link response: %w", err)
 	}
 
-	result := &LinkResult{
-		SourceID: pmid,
+	sets := make([]LinkSet, len(ids))
+	positions := make(map[string][]int, len(ids))
+	for i, id := range ids {
+		sets[i] = LinkSet{SourceID: id, DbFrom: dbFrom, LinkSetDBs: []LinkSetDB{}}
+		positions[id] = append(positions[id], i)
 	}
 
-	if len(resp.LinkSets) > 0 {
-		for _, lsdb := range resp.LinkSets[0].LinkSetDBs {
-			if lsdb.LinkName != linkName {
+	for _, ls := range resp.LinkSets {
+		if len(ls.IDs) == 0 {
+			continue
+		}
+		var dbs []LinkSetDB
+		for _, lsdb := range ls.LinkSetDBs {
+			if linkName != "" && lsdb.LinkName != linkName {
 				continue
 			}
+			db := LinkSetDB{DbTo: lsdb.DbTo, LinkName: lsdb.LinkName, Links: make([]LinkItem, 0, len(lsdb.Links))}
 			for _, link := range lsdb.Links {
 				item := LinkItem{
 					ID: link.id,
@@ -133,15 +263,14 @@ func (c *Client) link(ctx context.Context, pmid, linkName string, withScores boo
 				if link.score != "" {
 					item.Score, _ = strconv.Atoi(link.score)
 				}
-				result.Links = append(result.Links, item)
+				db.Links = append(db.Links, item)
 			}
+			dbs = append(dbs, db)
+		}
+		for _, i := range positions[ls.IDs[0]] {
+			sets[i].LinkSetDBs = append(sets[i].LinkSetDBs, dbs...)
 		}
 	}
 
-	// Ensure Links is non-nil empty slice for JSON serialization
-	if result.Links == nil {
-		result.Links = []LinkItem{}
-	}
-
-	return result, nil
+	return sets, nil
 }
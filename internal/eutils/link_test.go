@@ -166,6 +166,125 @@ func TestLink_MultipleLinkSetDBs_FiltersByLinkname(t *testing.T) {
 	}
 }
 
+func TestLink_CrossDatabaseMultipleIDs(t *testing.T) {
+	fixture := loadTestdata(t, "elink_pubmed_gene.json")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("db"); got != "gene" {
+			t.Errorf("expected db=gene, got %q", got)
+		}
+		if q.Has("linkname") {
+			if got := q.Get("linkname"); got != "pubmed_gene_rif" {
+				t.Errorf("expected linkname=pubmed_gene_rif, got %q", got)
+			}
+		} else if got := q["id"]; len(got) != 2 || got[0] != "37286542" || got[1] != "38123456" {
+			t.Errorf("expected one id parameter per source ID, got %v", got)
+		}
+		if q.Has("cmd") {
+			t.Errorf("unexpected cmd=%s", q.Get("cmd"))
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	sets, err := c.Link(context.Background(), []string{"37286542", "38123456"}, "pubmed", "gene", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sets) != 2 {
+		t.Fatalf("expected 2 link sets, got %d", len(sets))
+	}
+	first := sets[0]
+	if first.SourceID != "37286542" || len(first.LinkSetDBs) != 2 {
+		t.Fatalf("unexpected first link set: %+v", first)
+	}
+	if db := first.LinkSetDBs[0]; db.DbTo != "gene" || db.LinkName != "pubmed_gene" || len(db.Links) != 2 {
+		t.Errorf("unexpected pubmed_gene links: %+v", db)
+	}
+	if got := first.IDs(); len(got) != 2 || got[0] != "2332" || got[1] != "14265" {
+		t.Errorf("expected deduplicated IDs [2332 14265], got %v", got)
+	}
+	if second := sets[1]; second.SourceID != "38123456" || len(second.LinkSetDBs) != 0 {
+		t.Errorf("expected no links for second ID, got %+v", second)
+	}
+
+	sets, err = c.Link(context.Background(), []string{"37286542"}, "pubmed", "gene", "pubmed_gene_rif")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dbs := sets[0].LinkSetDBs; len(dbs) != 1 || dbs[0].LinkName != "pubmed_gene_rif" {
+		t.Errorf("expected only pubmed_gene_rif, got %+v", dbs)
+	}
+}
+
+func TestCitedByBatch(t *testing.T) {
+	fixture := loadTestdata(t, "elink_citedin_batch.json")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q["id"]; len(got) != 3 {
+			t.Errorf("expected 3 id parameters, got %v", got)
+		}
+		if got := q.Get("linkname"); got != "pubmed_pubmed_citedin" {
+			t.Errorf("expected linkname=pubmed_pubmed_citedin, got %q", got)
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	results, err := c.CitedByBatch(context.Background(), []string{"38000001", "38000002", "38000003"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].SourceID != "38000001" || len(results[0].Links) != 3 {
+		t.Errorf("unexpected first result: %+v", results[0])
+	}
+	if results[2].Links == nil || len(results[2].Links) != 0 {
+		t.Errorf("expected empty non-nil links for third PMID, got %#v", results[2].Links)
+	}
+
+	agg := AggregateLinks(results)
+	if len(agg) != 4 {
+		t.Fatalf("expected 4 unique targets, got %+v", agg)
+	}
+	if agg[0].ID != "39000002" || agg[0].Count != 2 || len(agg[0].Sources) != 2 {
+		t.Errorf("expected 39000002 cited by both sources first, got %+v", agg[0])
+	}
+	if agg[1].ID != "39000001" || agg[1].Count != 1 {
+		t.Errorf("expected ties in first-seen order, got %+v", agg[1])
+	}
+}
+
+func TestAggregateLinks_SkipsSelfLinksAndDuplicates(t *testing.T) {
+	results := []LinkResult{
+		{SourceID: "1", Links: []LinkItem{{ID: "1", Score: 100}, {ID: "5"}, {ID: "5"}}},
+		{SourceID: "1", Links: []LinkItem{{ID: "5"}}},
+	}
+	agg := AggregateLinks(results)
+	if len(agg) != 1 || agg[0].ID != "5" || agg[0].Count != 1 {
+		t.Errorf("expected one target counted once, got %+v", agg)
+	}
+	if got := AggregateLinks(nil); got == nil || len(got) != 0 {
+		t.Errorf("expected empty non-nil slice, got %#v", got)
+	}
+}
+
+func TestLink_InvalidArguments(t *testing.T) {
+	c := NewClient(WithAPIKey("test"))
+	if _, err := c.Link(context.Background(), nil, "pubmed", "gene", ""); err == nil {
+		t.Error("expected error for no IDs")
+	}
+	if _, err := c.Link(context.Background(), []string{"1"}, "pubmed", "", ""); err == nil {
+		t.Error("expected error for missing target database")
+	}
+}
+
 func TestLink_EmptyPMID(t *testing.T) {
 	c := NewClient(WithAPIKey("test"))
 
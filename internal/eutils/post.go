@@ -0,0 +1,75 @@
+package eutils
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// EPost XML response structure. EPost has no JSON mode.
+type epostResponse struct {
+	XMLName       xml.Name `xml:"ePostResult"`
+	QueryKey      string   `xml:"QueryKey"`
+	WebEnv        string   `xml:"WebEnv"`
+	InvalidIDList []string `xml:"InvalidIdList>Id"`
+	Error         string   `xml:"ERROR"`
+}
+
+// Post uploads a set of PMIDs to the NCBI history server via EPost and
+// returns the WebEnv/QueryKey handle for the stored set, in a new session.
+// The handle can be passed to Search (SearchOptions.WebEnv, referring to the
+// set as "#<key>"), FetchHistory or SummaryHistory.
+func (c *Client) Post(ctx context.Context, ids []string) (*PostResult, error) {
+	return c.PostHistory(ctx, "", ids)
+}
+
+// PostHistory uploads a set of PMIDs into an existing history session so it
+// can be combined with sets and searches already stored there. An empty
+// webEnv starts a new session, as in Post.
+func (c *Client) PostHistory(ctx context.Context, webEnv string, ids []string) (*PostResult, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one ID is required")
+	}
+
+	params := url.Values{}
+	params.Set("db", "pubmed")
+	if webEnv != "" {
+		params.Set("WebEnv", webEnv)
+	}
+
+	body, err := c.doWithIDs(ctx, "epost.fcgi", params, ids)
+	if err != nil {
+		return nil, fmt.Errorf("post request failed: %w", err)
+	}
+
+	var resp epostResponse
+	if err := xml.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing post response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("NCBI EPost error: %s", strings.TrimSpace(resp.Error))
+	}
+	if resp.WebEnv == "" || resp.QueryKey == "" {
+		return nil, fmt.Errorf("post response missing WebEnv or QueryKey")
+	}
+
+	invalid := make(map[string]struct{}, len(resp.InvalidIDList))
+	for _, id := range resp.InvalidIDList {
+		invalid[strings.TrimSpace(id)] = struct{}{}
+	}
+	posted := 0
+	for _, id := range dedupeIDs(ids) {
+		if _, bad := invalid[id]; !bad {
+			posted++
+		}
+	}
+
+	return &PostResult{
+		WebEnv:     resp.WebEnv,
+		QueryKey:   resp.QueryKey,
+		Count:      posted,
+		InvalidIDs: resp.InvalidIDList,
+	}, nil
+}
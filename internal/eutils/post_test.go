@@ -0,0 +1,96 @@
+package eutils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPost_ReturnsHistoryHandle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/epost.fcgi" {
+			t.Errorf("expected epost.fcgi, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if got := q.Get("id"); got != "38123456,35999876,1" {
+			t.Errorf("unexpected id param %q", got)
+		}
+		if got := q.Get("WebEnv"); got != "" {
+			t.Errorf("expected no WebEnv for a new session, got %q", got)
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8" ?>
+<ePostResult>
+	<InvalidIdList><Id>1</Id></InvalidIdList>
+	<QueryKey>1</QueryKey>
+	<WebEnv>MCID_post</WebEnv>
+</ePostResult>`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	result, err := c.Post(context.Background(), []string{"38123456", "35999876", "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.WebEnv != "MCID_post" || result.QueryKey != "1" {
+		t.Errorf("unexpected handle: %+v", result)
+	}
+	if result.Count != 2 {
+		t.Errorf("expected 2 posted IDs, got %d", result.Count)
+	}
+	if len(result.InvalidIDs) != 1 || result.InvalidIDs[0] != "1" {
+		t.Errorf("expected invalid IDs [1], got %v", result.InvalidIDs)
+	}
+}
+
+func TestPost_LargeSetUsesPOSTAndExistingWebEnv(t *testing.T) {
+	ids := make([]string, MaxGetIDs+1)
+	for i := range ids {
+		ids[i] = strconv.Itoa(30000000 + i)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		r.ParseForm()
+		if got := len(strings.Split(r.Form.Get("id"), ",")); got != len(ids) {
+			t.Errorf("expected %d IDs in form body, got %d", len(ids), got)
+		}
+		if got := r.Form.Get("WebEnv"); got != "MCID_existing" {
+			t.Errorf("expected WebEnv=MCID_existing, got %q", got)
+		}
+		fmt.Fprint(w, `<ePostResult><QueryKey>2</QueryKey><WebEnv>MCID_existing</WebEnv></ePostResult>`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	result, err := c.PostHistory(context.Background(), "MCID_existing", ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.QueryKey != "2" || result.Count != len(ids) {
+		t.Errorf("unexpected result: key=%s count=%d", result.QueryKey, result.Count)
+	}
+}
+
+func TestPost_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<ePostResult><ERROR>IDs contain invalid characters</ERROR></ePostResult>`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	_, err := c.Post(context.Background(), []string{"123"})
+	if err == nil || !strings.Contains(err.Error(), "invalid characters") {
+		t.Errorf("expected EPost error, got %v", err)
+	}
+
+	if _, err := c.Post(context.Background(), nil); err == nil {
+		t.Error("expected error for empty ID list")
+	}
+}
@@ -0,0 +1,172 @@
+package eutils
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// monthNumbers maps month names and abbreviations, as used in PubMed dates,
+// to month numbers.
+var monthNumbers = map[string]int{
+	"jan": 1, "january": 1,
+	"feb": 2, "february": 2,
+	"mar": 3, "march": 3,
+	"apr": 4, "april": 4,
+	"may": 5,
+	"jun": 6, "june": 6,
+	"jul": 7, "july": 7,
+	"aug": 8, "august": 8,
+	"sep": 9, "sept": 9, "september": 9,
+	"oct": 10, "october": 10,
+	"nov": 11, "november": 11,
+	"dec": 12, "december": 12,
+}
+
+// seasonMonths places a season at its first month for sorting. "Winter
+// YYYY" issues usually open the year, so winter sorts as January.
+var seasonMonths = map[string]int{
+	"winter": 1,
+	"spring": 3,
+	"summer": 6,
+	"fall":   9,
+	"autumn": 9,
+}
+
+// Time returns a best-estimate publication time for sorting. Missing month
+// and day default to the first; a season stands in for its month. When the
+// print date lacks a day, a matching electronic date is used instead since
+// it is more precise. The zero time means the date is unknown.
+func (d PubDate) Time() time.Time {
+	electronic := parseISODate(d.Electronic)
+	if d.Year == 0 {
+		return electronic
+	}
+
+	month := d.Month
+	if month == 0 {
+		month = seasonMonths[strings.ToLower(d.Season)]
+	}
+	if d.Day == 0 && !electronic.IsZero() && electronic.Year() == d.Year &&
+		(month == 0 || int(electronic.Month()) == month) {
+		return electronic
+	}
+
+	day := d.Day
+	if month == 0 {
+		month = 1
+	}
+	if day == 0 {
+		day = 1
+	}
+	return time.Date(d.Year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// ISO returns the print date as YYYY-MM-DD, YYYY-MM or YYYY depending on
+// its precision, falling back to the electronic date when there is no year.
+func (d PubDate) ISO() string {
+	if d.Year == 0 {
+		return d.Electronic
+	}
+	return isoDate(strconv.Itoa(d.Year), strconv.Itoa(d.Month), strconv.Itoa(d.Day))
+}
+
+// SortByPubDate orders articles newest first by PubDate.Time, keeping the
+// existing order for ties. Articles without a usable date go last.
+func SortByPubDate(articles []Article) {
+	slices.SortStableFunc(articles, func(a, b Article) int {
+		ta, tb := a.PubDate.Time(), b.PubDate.Time()
+		switch {
+		case ta.IsZero() && tb.IsZero():
+			return 0
+		case ta.IsZero():
+			return 1
+		case tb.IsZero():
+			return -1
+		}
+		return tb.Compare(ta)
+	})
+}
+
+// parseISODate parses YYYY-MM-DD, YYYY-MM or YYYY, returning the zero
+// time when s is empty or malformed.
+func parseISODate(s string) time.Time {
+	for _, layout := range []string{"2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// newPubDate converts an EFetch PubDate element. Structured Year/Month/Day
+// (or Season) are used when present; otherwise MedlineDate is parsed.
+func newPubDate(pd xmlPubDate) PubDate {
+	if strings.TrimSpace(pd.Year) == "" {
+		md := strings.TrimSpace(pd.MedlineDate)
+		if md == "" {
+			return PubDate{}
+		}
+		d := parseDateText(md)
+		d.MedlineDate = md
+		return d
+	}
+
+	d := PubDate{Season: strings.TrimSpace(pd.Season)}
+	d.Year, _ = strconv.Atoi(strings.TrimSpace(pd.Year))
+	d.Month = parseMonth(pd.Month)
+	if d.Month > 0 {
+		if day, err := strconv.Atoi(strings.TrimSpace(pd.Day)); err == nil && day >= 1 && day <= 31 {
+			d.Day = day
+		}
+	}
+	return d
+}
+
+// parseDateText parses free-text dates such as MedlineDate values ("2020
+// Jan-Feb", "1998 Dec-1999 Jan", "Winter 2020", "2019-2020") and ESummary
+// pubdates ("2024 Mar 15"). It keeps the first year and the month, day or
+// season that follow it, stopping at a second year so ranges resolve to
+// their start.
+func parseDateText(s string) PubDate {
+	var d PubDate
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '/' || r == ','
+	})
+	for _, f := range fields {
+		lower := strings.ToLower(strings.TrimSuffix(f, "."))
+		n, err := strconv.Atoi(f)
+		switch {
+		case err == nil && len(f) == 4:
+			if d.Year != 0 {
+				return d
+			}
+			d.Year = n
+		case err == nil && d.Month > 0 && d.Day == 0 && n >= 1 && n <= 31:
+			d.Day = n
+		case monthNumbers[lower] > 0:
+			if d.Month == 0 {
+				d.Month = monthNumbers[lower]
+			} else if d.Year != 0 {
+				// Second month of a range such as "Jan-Feb".
+				return d
+			}
+		case seasonMonths[lower] > 0 && d.Season == "":
+			d.Season = strings.ToUpper(lower[:1]) + lower[1:]
+		}
+	}
+	return d
+}
+
+// parseMonth accepts a month as a number ("03") or name ("Mar").
+func parseMonth(s string) int {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		if n >= 1 && n <= 12 {
+			return n
+		}
+		return 0
+	}
+	return monthNumbers[strings.ToLower(s)]
+}
@@ -0,0 +1,121 @@
+package eutils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDateText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want PubDate
+	}{
+		{"2024 Mar 15", PubDate{Year: 2024, Month: 3, Day: 15}},
+		{"2024 Mar", PubDate{Year: 2024, Month: 3}},
+		{"2024", PubDate{Year: 2024}},
+		{"2020 Jan-Feb", PubDate{Year: 2020, Month: 1}},
+		{"1998 Dec-1999 Jan", PubDate{Year: 1998, Month: 12}},
+		{"2019-2020", PubDate{Year: 2019}},
+		{"Winter 2020", PubDate{Year: 2020, Season: "Winter"}},
+		{"2021 Fall", PubDate{Year: 2021, Season: "Fall"}},
+		{"2015 Sept 3", PubDate{Year: 2015, Month: 9, Day: 3}},
+		{"", PubDate{}},
+	}
+	for _, tt := range tests {
+		if got := parseDateText(tt.in); got != tt.want {
+			t.Errorf("parseDateText(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNewPubDate(t *testing.T) {
+	tests := []struct {
+		in   xmlPubDate
+		want PubDate
+	}{
+		{xmlPubDate{Year: "2023", Month: "Jun", Day: "05"}, PubDate{Year: 2023, Month: 6, Day: 5}},
+		{xmlPubDate{Year: "2023", Month: "06"}, PubDate{Year: 2023, Month: 6}},
+		{xmlPubDate{Year: "2020", Season: "Spring"}, PubDate{Year: 2020, Season: "Spring"}},
+		// A day without a month is dropped.
+		{xmlPubDate{Year: "2020", Day: "12"}, PubDate{Year: 2020}},
+		{xmlPubDate{MedlineDate: "2020 Jan-Feb"}, PubDate{Year: 2020, Month: 1, MedlineDate: "2020 Jan-Feb"}},
+		{xmlPubDate{}, PubDate{}},
+	}
+	for _, tt := range tests {
+		if got := newPubDate(tt.in); got != tt.want {
+			t.Errorf("newPubDate(%+v) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPubDate_Time(t *testing.T) {
+	day := func(y, m, d int) time.Time { return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name string
+		in   PubDate
+		want time.Time
+	}{
+		{"full date", PubDate{Year: 2024, Month: 3, Day: 15}, day(2024, 3, 15)},
+		{"year only", PubDate{Year: 2024}, day(2024, 1, 1)},
+		{"season", PubDate{Year: 2020, Season: "Summer"}, day(2020, 6, 1)},
+		{"electronic refines month", PubDate{Year: 2024, Month: 3, Electronic: "2024-03-20"}, day(2024, 3, 20)},
+		{"electronic refines year", PubDate{Year: 2024, Electronic: "2024-02-11"}, day(2024, 2, 11)},
+		{"electronic in other month ignored", PubDate{Year: 2024, Month: 3, Electronic: "2023-12-01"}, day(2024, 3, 1)},
+		{"print day wins", PubDate{Year: 2024, Month: 3, Day: 2, Electronic: "2024-03-20"}, day(2024, 3, 2)},
+		{"electronic only", PubDate{Electronic: "2022-07"}, day(2022, 7, 1)},
+		{"unknown", PubDate{}, time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := tt.in.Time(); !got.Equal(tt.want) {
+			t.Errorf("%s: Time() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPubDate_ISO(t *testing.T) {
+	tests := []struct {
+		in   PubDate
+		want string
+	}{
+		{PubDate{Year: 2024, Month: 3, Day: 5}, "2024-03-05"},
+		{PubDate{Year: 2024, Month: 3}, "2024-03"},
+		{PubDate{Year: 2020, Season: "Winter"}, "2020"},
+		{PubDate{Electronic: "2022-07-01"}, "2022-07-01"},
+		{PubDate{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.in.ISO(); got != tt.want {
+			t.Errorf("%+v.ISO() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSortByPubDate(t *testing.T) {
+	articles := []Article{
+		{PMID: "undated"},
+		{PMID: "2023", PubDate: PubDate{Year: 2023, Month: 6}},
+		{PMID: "2024-mar", PubDate: PubDate{Year: 2024, Month: 3}},
+		{PMID: "2024-mar-epub", PubDate: PubDate{Year: 2024, Month: 3, Electronic: "2024-03-28"}},
+		{PMID: "2024-winter", PubDate: PubDate{Year: 2024, Season: "Winter"}},
+		{PMID: "2023-tie", PubDate: PubDate{Year: 2023, Month: 6}},
+	}
+	SortByPubDate(articles)
+
+	got := make([]string, len(articles))
+	for i, a := range articles {
+		got[i] = a.PMID
+	}
+	want := "2024-mar-epub,2024-mar,2024-winter,2023,2023-tie,undated"
+	if strings.Join(got, ",") != want {
+		t.Errorf("expected order %s, got %s", want, strings.Join(got, ","))
+	}
+}
+
+func TestDocSummary_PubDate(t *testing.T) {
+	a := DocSummary{PMID: "1", PubDate: "2024 Mar", EPubDate: "2024 Mar 12"}.Article()
+	want := PubDate{Year: 2024, Month: 3, Electronic: "2024-03-12"}
+	if a.PubDate != want {
+		t.Errorf("expected %+v, got %+v", want, a.PubDate)
+	}
+}
@@ -8,6 +8,13 @@ import (
 	"strconv"
 )
 
+const (
+	// SearchPageSize is the number of IDs requested per page by SearchAll.
+	SearchPageSize = 1000
+	// MaxSearchResults is the deepest position ESearch will page to in PubMed.
+	MaxSearchResults = 10000
+)
+
 // esearchResponse represents the raw JSON response from ESearch.
 type esearchResponse struct {
 	Result esearchResult `json:"esearchresult"`
@@ -23,7 +30,9 @@ type esearchResult struct {
 	QueryKey         string   `json:"querykey"`
 }
 
-// Search performs an ESearch query against PubMed.
+// Search performs an ESearch query against PubMed. When opts.WebEnv is set
+// the search runs inside that history session, so the query can combine
+// earlier result sets or EPost uploads by key (e.g. "#1 AND autism[mh]").
 func (c *Client) Search(ctx context.Context, query string, opts *SearchOptions) (*SearchResult, error) {
 	if query == "" {
 		return nil, fmt.Errorf("search query cannot be empty")
@@ -36,10 +45,18 @@ func (c *Client) Search(ctx context.Context, query string, opts *SearchOptions)
 	params.Set("usehistory", "y")
 
 	limit := 20
+	retStart := 0
 	if opts != nil {
 		if opts.Limit > 0 {
 			limit = opts.Limit
 		}
+		if opts.RetStart > 0 {
+			retStart = opts.RetStart
+			params.Set("retstart", strconv.Itoa(retStart))
+		}
+		if opts.WebEnv != "" {
+			params.Set("WebEnv", opts.WebEnv)
+		}
 		if opts.Sort != "" {
 			params.Set("sort", opts.Sort)
 		}
@@ -72,9 +89,50 @@ func (c *Client) Search(ctx context.Context, query string, opts *SearchOptions)
 
 	return &SearchResult{
 		Count:            count,
+		Offset:           retStart,
 		IDs:              resp.Result.IDList,
 		QueryTranslation: resp.Result.QueryTranslation,
 		WebEnv:           resp.Result.WebEnv,
 		QueryKey:         resp.Result.QueryKey,
 	}, nil
 }
+
+// SearchAll pages through every result of query using retstart, starting at
+// opts.RetStart. Pages after the first reuse the WebEnv returned by the
+// initial request so the whole walk stays on one history-server session.
+// opts.Limit is ignored; pages are SearchPageSize IDs each. PubMed serves at
+// most MaxSearchResults IDs per query, so very large result sets are
+// truncated there (Count still reports the full total).
+func (c *Client) SearchAll(ctx context.Context, query string, opts *SearchOptions) (*SearchResult, error) {
+	var o SearchOptions
+	if opts != nil {
+		o = *opts
+	}
+	o.Limit = SearchPageSize
+
+	result, err := c.Search(ctx, query, &o)
+	if err != nil {
+		return nil, err
+	}
+	ids := append([]string(nil), result.IDs...)
+	o.WebEnv = result.WebEnv
+
+	for next := o.RetStart + len(result.IDs); next < result.Count && next < MaxSearchResults; {
+		o.RetStart = next
+		if remaining := MaxSearchResults - next; remaining < o.Limit {
+			o.Limit = remaining
+		}
+		page, err := c.Search(ctx, query, &o)
+		if err != nil {
+			return nil, fmt.Errorf("fetching results from offset %d: %w", next, err)
+		}
+		if len(page.IDs) == 0 {
+			break
+		}
+		ids = append(ids, page.IDs...)
+		next += len(page.IDs)
+	}
+
+	result.IDs = ids
+	return result, nil
+}
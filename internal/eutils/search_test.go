@@ -2,11 +2,13 @@ package eutils
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 )
 
@@ -195,3 +197,73 @@ func TestSearch_RateLimitError(t *testing.T) {
 		t.Error("expected error for rate limit, got nil")
 	}
 }
+
+func TestSearch_Offset(t *testing.T) {
+	var receivedParams url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedParams = r.URL.Query()
+		w.Write(loadTestdata(t, "esearch_response.json"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	result, err := c.Search(context.Background(), "test", &SearchOptions{RetStart: 40, WebEnv: "MCID_existing"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := receivedParams.Get("retstart"); got != "40" {
+		t.Errorf("expected retstart=40, got %q", got)
+	}
+	if got := receivedParams.Get("WebEnv"); got != "MCID_existing" {
+		t.Errorf("expected WebEnv=MCID_existing, got %q", got)
+	}
+	if result.Offset != 40 {
+		t.Errorf("expected offset 40, got %d", result.Offset)
+	}
+}
+
+func TestSearchAll_PagesThroughResults(t *testing.T) {
+	const total = SearchPageSize + 3
+	var starts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		starts = append(starts, q.Get("retstart"))
+		if len(starts) > 1 && q.Get("WebEnv") != "MCID_page" {
+			t.Errorf("expected follow-up page to reuse WebEnv, got %q", q.Get("WebEnv"))
+		}
+
+		start, _ := strconv.Atoi(q.Get("retstart"))
+		max, _ := strconv.Atoi(q.Get("retmax"))
+		var ids []string
+		for i := start; i < start+max && i < total; i++ {
+			ids = append(ids, strconv.Itoa(100000+i))
+		}
+		resp := map[string]any{"esearchresult": map[string]any{
+			"count":    strconv.Itoa(total),
+			"idlist":   ids,
+			"webenv":   "MCID_page",
+			"querykey": "1",
+		}}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	result, err := c.SearchAll(context.Background(), "autism", &SearchOptions{Limit: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.IDs) != total {
+		t.Fatalf("expected %d IDs, got %d", total, len(result.IDs))
+	}
+	if result.IDs[total-1] != strconv.Itoa(100000+total-1) {
+		t.Errorf("unexpected last ID %q", result.IDs[total-1])
+	}
+	if len(starts) != 2 || starts[1] != strconv.Itoa(SearchPageSize) {
+		t.Errorf("expected two pages starting at 0 and %d, got %v", SearchPageSize, starts)
+	}
+	if result.WebEnv != "MCID_page" || result.QueryKey != "1" {
+		t.Errorf("expected history handle to be preserved, got %q/%q", result.WebEnv, result.QueryKey)
+	}
+}
@@ -0,0 +1,75 @@
+package eutils
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ESpell XML response structure. ESpell has no JSON mode.
+type espellResponse struct {
+	XMLName        xml.Name       `xml:"eSpellResult"`
+	Query          string         `xml:"Query"`
+	CorrectedQuery string         `xml:"CorrectedQuery"`
+	SpelledQuery   espellSegments `xml:"SpelledQuery"`
+	Error          string         `xml:"ERROR"`
+}
+
+// espellSegments holds the ordered <Original>/<Replaced> runs of a query.
+type espellSegments struct {
+	Segments []espellSegment `xml:",any"`
+}
+
+type espellSegment struct {
+	XMLName xml.Name
+	Text    string `xml:",chardata"`
+}
+
+// Spell asks ESpell for a spelling-corrected version of query. It returns an
+// empty string when ESpell has no suggestion. The suggestion is rebuilt from
+// ESpell's segment list so that untouched parts of the query, including
+// upper-case Boolean operators and field tags, are preserved verbatim.
+func (c *Client) Spell(ctx context.Context, query string) (string, error) {
+	if strings.TrimSpace(query) == "" {
+		return "", fmt.Errorf("spell query cannot be empty")
+	}
+
+	params := url.Values{}
+	params.Set("db", "pubmed")
+	params.Set("term", query)
+
+	body, err := c.DoGet(ctx, "espell.fcgi", params)
+	if err != nil {
+		return "", fmt.Errorf("spell request failed: %w", err)
+	}
+
+	var resp espellResponse
+	if err := xml.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("parsing spell response: %w", err)
+	}
+	if msg := strings.TrimSpace(resp.Error); msg != "" {
+		return "", fmt.Errorf("NCBI ESpell error: %s", msg)
+	}
+
+	var (
+		b        strings.Builder
+		replaced bool
+	)
+	for _, seg := range resp.SpelledQuery.Segments {
+		if seg.XMLName.Local == "Replaced" {
+			replaced = true
+		}
+		b.WriteString(seg.Text)
+	}
+
+	suggestion := strings.TrimSpace(b.String())
+	if !replaced {
+		suggestion = strings.TrimSpace(resp.CorrectedQuery)
+	}
+	if suggestion == "" || strings.EqualFold(suggestion, strings.TrimSpace(query)) {
+		return "", nil
+	}
+	return suggestion, nil
+}
@@ -0,0 +1,58 @@
+package eutils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSpell_PreservesBooleanOperators(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/espell.fcgi" {
+			t.Errorf("expected espell.fcgi, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("term"); got != "asthmaa AND childern[tiab]" {
+			t.Errorf("unexpected term %q", got)
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8" ?>
+<eSpellResult>
+	<Database>pubmed</Database>
+	<Query>asthmaa AND childern[tiab]</Query>
+	<CorrectedQuery>asthma and children[tiab]</CorrectedQuery>
+	<SpelledQuery><Original></Original><Replaced>asthma</Replaced><Original> AND </Original><Replaced>children</Replaced><Original>[tiab]</Original></SpelledQuery>
+	<ERROR/>
+</eSpellResult>`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	got, err := c.Spell(context.Background(), "asthmaa AND childern[tiab]")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "asthma AND children[tiab]"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSpell_NoSuggestion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<eSpellResult><Database>pubmed</Database><Query>asthma OR copd</Query><CorrectedQuery>asthma or copd</CorrectedQuery><SpelledQuery><Original>asthma OR copd</Original></SpelledQuery><ERROR/></eSpellResult>`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	got, err := c.Spell(context.Background(), "asthma OR copd")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("expected no suggestion, got %q", got)
+	}
+
+	if _, err := c.Spell(context.Background(), "  "); err == nil {
+		t.Error("expected error for empty query")
+	}
+}
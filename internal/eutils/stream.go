@@ -0,0 +1,108 @@
+package eutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// StreamPageSize is the number of records requested per EFetch call when
+// streaming from the history server. Each page is decoded as it arrives,
+// so the page size bounds request count, not memory.
+const StreamPageSize = 1000
+
+// errStopped signals that the consumer of a stream stopped iterating.
+var errStopped = errors.New("stream stopped")
+
+// FetchStream retrieves full article details for pmids and yields them one
+// at a time as each EFetch response is decoded, instead of buffering whole
+// responses. IDs are requested in chunks of DefaultBatchSize. Iteration ends
+// at the first error, which is yielded with a zero Article.
+func (c *Client) FetchStream(ctx context.Context, pmids []string) iter.Seq2[Article, error] {
+	return func(yield func(Article, error) bool) {
+		if len(pmids) == 0 {
+			yield(Article{}, fmt.Errorf("at least one PMID is required"))
+			return
+		}
+
+		for _, chunk := range chunkIDs(pmids, DefaultBatchSize) {
+			params := url.Values{}
+			params.Set("db", "pubmed")
+			params.Set("rettype", "xml")
+			params.Set("retmode", "xml")
+			params.Set("id", strings.Join(chunk, ","))
+
+			if _, err := c.streamPage(ctx, params, yield); err != nil {
+				if !errors.Is(err, errStopped) {
+					yield(Article{}, fmt.Errorf("fetch stream failed: %w", err))
+				}
+				return
+			}
+		}
+	}
+}
+
+// FetchHistoryStream yields articles at positions start..start+max-1 of a
+// history-server result set, requesting StreamPageSize records at a time.
+// A max of 0 or less streams to the end of the set.
+func (c *Client) FetchHistoryStream(ctx context.Context, webEnv, queryKey string, start, max int) iter.Seq2[Article, error] {
+	return func(yield func(Article, error) bool) {
+		if webEnv == "" || queryKey == "" {
+			yield(Article{}, fmt.Errorf("WebEnv and query key are required"))
+			return
+		}
+
+		for offset := start; max <= 0 || offset < start+max; {
+			n := StreamPageSize
+			if max > 0 {
+				if remaining := start + max - offset; remaining < n {
+					n = remaining
+				}
+			}
+
+			params := url.Values{}
+			params.Set("db", "pubmed")
+			params.Set("WebEnv", webEnv)
+			params.Set("query_key", queryKey)
+			params.Set("retstart", strconv.Itoa(offset))
+			params.Set("retmax", strconv.Itoa(n))
+			params.Set("rettype", "xml")
+			params.Set("retmode", "xml")
+
+			count, err := c.streamPage(ctx, params, yield)
+			if err != nil {
+				if !errors.Is(err, errStopped) {
+					yield(Article{}, fmt.Errorf("history fetch stream failed at offset %d: %w", offset, err))
+				}
+				return
+			}
+			// An empty page means the set is exhausted.
+			if count == 0 {
+				return
+			}
+			offset += n
+		}
+	}
+}
+
+// streamPage issues one EFetch request and yields each decoded article. It
+// returns the number of articles yielded.
+func (c *Client) streamPage(ctx context.Context, params url.Values, yield func(Article, error) bool) (int, error) {
+	body, err := c.DoStream(ctx, http.MethodGet, "efetch.fcgi", params)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	count := 0
+	err = decodeArticleSet(body, func(a Article) bool {
+		count++
+		return yield(a, nil)
+	}, nil)
+	return count, err
+}
@@ -0,0 +1,193 @@
+package eutils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFetchStream_ChunksAndOrder(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		ids := strings.Split(r.URL.Query().Get("id"), ",")
+		if len(ids) > DefaultBatchSize {
+			t.Errorf("expected at most %d IDs per request, got %d", DefaultBatchSize, len(ids))
+		}
+		w.Write([]byte(minimalArticleSetXML(ids)))
+	}))
+	defer srv.Close()
+
+	pmids := make([]string, 250)
+	for i := range pmids {
+		pmids[i] = strconv.Itoa(i + 1)
+	}
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	var got []string
+	for a, err := range c.FetchStream(context.Background(), pmids) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, a.PMID)
+	}
+
+	if len(got) != 250 {
+		t.Fatalf("expected 250 articles, got %d", len(got))
+	}
+	// minimalArticleSetXML reverses each chunk; streaming keeps response order.
+	if got[0] != "200" || got[199] != "1" || got[200] != "250" {
+		t.Errorf("unexpected order: first=%s, 200th=%s, 201st=%s", got[0], got[199], got[200])
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestFetchStream_BreakStopsRequests(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(minimalArticleSetXML(strings.Split(r.URL.Query().Get("id"), ","))))
+	}))
+	defer srv.Close()
+
+	pmids := make([]string, 450)
+	for i := range pmids {
+		pmids[i] = strconv.Itoa(i + 1)
+	}
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	n := 0
+	for _, err := range c.FetchStream(context.Background(), pmids) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n++; n == 3 {
+			break
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected iteration to stop after the first request, got %d requests", got)
+	}
+}
+
+func TestFetchStream_MalformedXML(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<PubmedArticleSet><PubmedArticle><MedlineCitation><PMID>1</PMID></MedlineCitation></PubmedArticle><PubmedArticle><MedlineCitation>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	var (
+		articles int
+		lastErr  error
+	)
+	for _, err := range c.FetchStream(context.Background(), []string{"1", "2"}) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		articles++
+	}
+
+	if articles != 1 {
+		t.Errorf("expected the complete record before the error, got %d", articles)
+	}
+	if lastErr == nil || !strings.Contains(lastErr.Error(), "parsing PubMed XML") {
+		t.Errorf("expected XML parse error, got %v", lastErr)
+	}
+}
+
+func TestFetchHistoryStream_Pages(t *testing.T) {
+	const total = 2500
+	var starts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("WebEnv") != "MCID_1" || q.Get("query_key") != "1" {
+			t.Errorf("unexpected history params: %v", q)
+		}
+		starts = append(starts, q.Get("retstart"))
+		start, _ := strconv.Atoi(q.Get("retstart"))
+		max, _ := strconv.Atoi(q.Get("retmax"))
+
+		var ids []string
+		for i := start; i < start+max && i < total; i++ {
+			ids = append(ids, strconv.Itoa(i+1))
+		}
+		w.Write([]byte(minimalArticleSetXML(ids)))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	count := 0
+	for _, err := range c.FetchHistoryStream(context.Background(), "MCID_1", "1", 0, 0) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+	}
+
+	if count != total {
+		t.Errorf("expected %d articles, got %d", total, count)
+	}
+	// Unbounded streams stop at the first empty page.
+	want := []string{"0", "1000", "2000", "3000"}
+	if strings.Join(starts, ",") != strings.Join(want, ",") {
+		t.Errorf("expected retstarts %v, got %v", want, starts)
+	}
+}
+
+func TestFetchHistoryStream_Max(t *testing.T) {
+	var retmax []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		retmax = append(retmax, q.Get("retmax"))
+		start, _ := strconv.Atoi(q.Get("retstart"))
+		max, _ := strconv.Atoi(q.Get("retmax"))
+		var ids []string
+		for i := start; i < start+max; i++ {
+			ids = append(ids, strconv.Itoa(i+1))
+		}
+		w.Write([]byte(minimalArticleSetXML(ids)))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	count := 0
+	for _, err := range c.FetchHistoryStream(context.Background(), "MCID_1", "1", 0, 1200) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+	}
+
+	if count != 1200 {
+		t.Errorf("expected 1200 articles, got %d", count)
+	}
+	if strings.Join(retmax, ",") != "1000,200" {
+		t.Errorf("expected retmax 1000,200, got %v", retmax)
+	}
+}
+
+func TestFetchStream_Empty(t *testing.T) {
+	c := NewClient(WithAPIKey("test"))
+	for _, err := range c.FetchStream(context.Background(), nil) {
+		if err == nil {
+			t.Error("expected error for empty PMIDs")
+		}
+	}
+	for _, err := range c.FetchHistoryStream(context.Background(), "", "1", 0, 0) {
+		if err == nil {
+			t.Error("expected error for missing WebEnv")
+		}
+	}
+}
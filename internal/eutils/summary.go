@@ -0,0 +1,366 @@
+package eutils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// summaryPageSize is the number of records requested per ESummary page
+// when reading from the history server.
+const summaryPageSize = 500
+
+// ESummary JSON response structures.
+type esummaryResponse struct {
+	Result map[string]json.RawMessage `json:"result"`
+}
+
+type esummaryDoc struct {
+	UID             string              `json:"uid"`
+	Error           string              `json:"error"`
+	PubDate         string              `json:"pubdate"`
+	EPubDate        string              `json:"epubdate"`
+	Source          string              `json:"source"`
+	FullJournalName string              `json:"fulljournalname"`
+	Authors         []esummaryAuthor    `json:"authors"`
+	Title           string              `json:"title"`
+	Volume          string              `json:"volume"`
+	Issue           string              `json:"issue"`
+	Pages           string              `json:"pages"`
+	Lang            []string            `json:"lang"`
+	PubType         []string            `json:"pubtype"`
+	ArticleIDs      []esummaryArticleID `json:"articleids"`
+	History         []esummaryHistory   `json:"history"`
+	ELocationID     string              `json:"elocationid"`
+}
+
+type esummaryAuthor struct {
+	Name     string `json:"name"`
+	AuthType string `json:"authtype"`
+}
+
+type esummaryArticleID struct {
+	IDType string `json:"idtype"`
+	Value  string `json:"value"`
+}
+
+type esummaryHistory struct {
+	PubStatus string `json:"pubstatus"`
+	Date      string `json:"date"`
+}
+
+// Summary retrieves lightweight document summaries for the given PMIDs via
+// ESummary. It is much cheaper than Fetch when abstracts and MeSH terms are
+// not needed. Summaries are returned in NCBI's order; IDs NCBI cannot
+// summarize are omitted.
+func (c *Client) Summary(ctx context.Context, pmids []string) ([]DocSummary, error) {
+	if len(pmids) == 0 {
+		return nil, fmt.Errorf("at least one PMID is required")
+	}
+
+	params := url.Values{}
+	params.Set("db", "pubmed")
+	params.Set("retmode", "json")
+
+	body, err := c.doWithIDs(ctx, "esummary.fcgi", params, pmids)
+	if err != nil {
+		return nil, fmt.Errorf("summary request failed: %w", err)
+	}
+
+	return parseSummaries(body)
+}
+
+// RecordSummaries retrieves ESummary records from any Entrez database and
+// reduces them to an accession, title and organism. Records are returned
+// in NCBI's order; IDs NCBI cannot summarize are omitted.
+func (c *Client) RecordSummaries(ctx context.Context, db string, ids []string) ([]RecordSummary, error) {
+	if db == "" {
+		return nil, fmt.Errorf("database is required")
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one ID is required")
+	}
+
+	params := url.Values{}
+	params.Set("db", db)
+	params.Set("retmode", "json")
+
+	body, err := c.doWithIDs(ctx, "esummary.fcgi", params, ids)
+	if err != nil {
+		return nil, fmt.Errorf("summary request failed: %w", err)
+	}
+
+	var resp esummaryResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing summary response: %w", err)
+	}
+	var uids []string
+	if raw, ok := resp.Result["uids"]; ok {
+		if err := json.Unmarshal(raw, &uids); err != nil {
+			return nil, fmt.Errorf("parsing summary uids: %w", err)
+		}
+	}
+
+	records := make([]RecordSummary, 0, len(uids))
+	for _, uid := range uids {
+		raw, ok := resp.Result[uid]
+		if !ok {
+			continue
+		}
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return nil, fmt.Errorf("parsing summary %s: %w", uid, err)
+		}
+		if docString(doc, "error") != "" {
+			continue
+		}
+		records = append(records, convertRecordSummary(db, uid, doc))
+	}
+	return records, nil
+}
+
+// convertRecordSummary picks the label fields of a docsum. Field names
+// differ by database: Gene has name (the symbol) and description, sequence
+// databases have caption and title, ClinVar and GDS have accession and
+// title, and PMC lists its PMCID among the article IDs.
+func convertRecordSummary(db, uid string, doc map[string]json.RawMessage) RecordSummary {
+	r := RecordSummary{
+		DB:        db,
+		UID:       uid,
+		Accession: firstNonEmpty(docString(doc, "accessionversion"), docString(doc, "caption"), docString(doc, "accession"), docString(doc, "name")),
+		Title:     firstNonEmpty(docString(doc, "title"), docString(doc, "description")),
+	}
+
+	if raw, ok := doc["organism"]; ok {
+		var name string
+		if err := json.Unmarshal(raw, &name); err == nil {
+			r.Organism = name
+		} else {
+			var org struct {
+				ScientificName string `json:"scientificname"`
+			}
+			if json.Unmarshal(raw, &org) == nil {
+				r.Organism = org.ScientificName
+			}
+		}
+	}
+
+	if r.Accession == "" {
+		if raw, ok := doc["articleids"]; ok {
+			var aids []esummaryArticleID
+			if json.Unmarshal(raw, &aids) == nil {
+				for _, aid := range aids {
+					if aid.IDType == "pmcid" {
+						r.Accession = aid.Value
+					}
+				}
+			}
+		}
+	}
+	return r
+}
+
+// docString returns the string value of key, or "" when it is absent or
+// not a string.
+func docString(doc map[string]json.RawMessage, key string) string {
+	var s string
+	if raw, ok := doc[key]; ok && json.Unmarshal(raw, &s) == nil {
+		return strings.TrimSpace(s)
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// SummaryHistory retrieves summaries for positions start..start+max-1 of a
+// result set stored on the history server.
+func (c *Client) SummaryHistory(ctx context.Context, webEnv, queryKey string, start, max int) ([]DocSummary, error) {
+	if webEnv == "" || queryKey == "" {
+		return nil, fmt.Errorf("WebEnv and query key are required")
+	}
+	if max <= 0 {
+		return nil, fmt.Errorf("retmax must be greater than 0")
+	}
+
+	var summaries []DocSummary
+	for offset := start; offset < start+max; offset += summaryPageSize {
+		n := summaryPageSize
+		if remaining := start + max - offset; remaining < n {
+			n = remaining
+		}
+
+		params := url.Values{}
+		params.Set("db", "pubmed")
+		params.Set("retmode", "json")
+		params.Set("WebEnv", webEnv)
+		params.Set("query_key", queryKey)
+		params.Set("retstart", strconv.Itoa(offset))
+		params.Set("retmax", strconv.Itoa(n))
+
+		body, err := c.DoGet(ctx, "esummary.fcgi", params)
+		if err != nil {
+			return nil, fmt.Errorf("history summary request failed: %w", err)
+		}
+		page, err := parseSummaries(body)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		summaries = append(summaries, page...)
+	}
+
+	return summaries, nil
+}
+
+// parseSummaries decodes an ESummary JSON payload, following the "uids"
+// order and skipping per-record errors.
+func parseSummaries(data []byte) ([]DocSummary, error) {
+	var resp esummaryResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing summary response: %w", err)
+	}
+
+	var uids []string
+	if raw, ok := resp.Result["uids"]; ok {
+		if err := json.Unmarshal(raw, &uids); err != nil {
+			return nil, fmt.Errorf("parsing summary uids: %w", err)
+		}
+	}
+
+	summaries := make([]DocSummary, 0, len(uids))
+	for _, uid := range uids {
+		raw, ok := resp.Result[uid]
+		if !ok {
+			continue
+		}
+		var doc esummaryDoc
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return nil, fmt.Errorf("parsing summary %s: %w", uid, err)
+		}
+		if doc.Error != "" {
+			continue
+		}
+		summaries = append(summaries, convertSummary(doc))
+	}
+
+	return summaries, nil
+}
+
+func convertSummary(doc esummaryDoc) DocSummary {
+	s := DocSummary{
+		PMID:            doc.UID,
+		Title:           doc.Title,
+		Source:          doc.Source,
+		FullJournalName: doc.FullJournalName,
+		PubDate:         doc.PubDate,
+		EPubDate:        doc.EPubDate,
+		Volume:          doc.Volume,
+		Issue:           doc.Issue,
+		Pages:           doc.Pages,
+		ELocationID:     doc.ELocationID,
+		PubTypes:        doc.PubType,
+	}
+
+	if len(doc.Lang) > 0 {
+		s.Language = doc.Lang[0]
+	}
+
+	for _, au := range doc.Authors {
+		s.Authors = append(s.Authors, SummaryAuthor{
+			Name:       au.Name,
+			Collective: au.AuthType == "CollectiveName",
+		})
+	}
+
+	for _, aid := range doc.ArticleIDs {
+		switch aid.IDType {
+		case "doi":
+			s.DOI = aid.Value
+		case "pmc":
+			s.PMCID = aid.Value
+		}
+	}
+
+	for _, h := range doc.History {
+		s.History = append(s.History, HistoryDate{Status: h.PubStatus, Date: summaryDate(h.Date)})
+	}
+
+	return s
+}
+
+// summaryDate converts ESummary's "YYYY/MM/DD HH:MM" history dates to the
+// YYYY-MM-DD form used by EFetch records, leaving other values untouched.
+func summaryDate(raw string) string {
+	if t, err := time.Parse("2006/01/02 15:04", strings.TrimSpace(raw)); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return raw
+}
+
+// Year returns the four-digit publication year from PubDate.
+func (s DocSummary) Year() string {
+	return yearRe.FindString(s.PubDate)
+}
+
+// pubDate parses the ESummary pubdate and epubdate strings ("2024 Mar 15").
+func (s DocSummary) pubDate() PubDate {
+	d := parseDateText(s.PubDate)
+	if e := parseDateText(s.EPubDate); e.Year != 0 {
+		d.Electronic = e.ISO()
+	}
+	return d
+}
+
+// Article converts the summary into a partial Article (no abstract or MeSH
+// terms) so it can be rendered by table and CSV formatters.
+func (s DocSummary) Article() Article {
+	a := Article{
+		PMID:             s.PMID,
+		Title:            s.Title,
+		Journal:          s.FullJournalName,
+		JournalAbbrev:    s.Source,
+		Volume:           s.Volume,
+		Issue:            s.Issue,
+		Pages:            s.Pages,
+		Year:             s.Year(),
+		PubDate:          s.pubDate(),
+		DOI:              s.DOI,
+		PMCID:            s.PMCID,
+		PublicationTypes: s.PubTypes,
+		Language:         s.Language,
+		Retracted:        hasRetractedType(s.PubTypes),
+	}
+	if a.Journal == "" {
+		a.Journal = s.Source
+	}
+	if fields := strings.Fields(s.PubDate); len(fields) > 1 {
+		a.Month = fields[1]
+	}
+	for _, au := range s.Authors {
+		if au.Collective {
+			a.Authors = append(a.Authors, Author{CollectiveName: au.Name})
+			continue
+		}
+		// ESummary names are "LastName Initials"; last names may contain spaces.
+		author := Author{LastName: au.Name}
+		if i := strings.LastIndex(au.Name, " "); i > 0 {
+			author.LastName = au.Name[:i]
+			author.Initials = au.Name[i+1:]
+		}
+		a.Authors = append(a.Authors, author)
+	}
+	return a
+}
@@ -0,0 +1,194 @@
+package eutils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSummary_ParsesDocSummaries(t *testing.T) {
+	fixture := loadTestdata(t, "esummary_pubmed.json")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/esummary.fcgi" {
+			t.Errorf("expected esummary.fcgi, got %s", r.URL.Path)
+		}
+		if got := q.Get("retmode"); got != "json" {
+			t.Errorf("expected retmode=json, got %q", got)
+		}
+		if got := q.Get("id"); got != "38123456,35999876,99999999" {
+			t.Errorf("unexpected id param %q", got)
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	summaries, err := c.Summary(context.Background(), []string{"38123456", "35999876", "99999999"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The error record for 99999999 is skipped.
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 summaries, got %d", len(summaries))
+	}
+	if summaries[0].PMID != "38123456" || summaries[1].PMID != "35999876" {
+		t.Errorf("unexpected order: %s, %s", summaries[0].PMID, summaries[1].PMID)
+	}
+
+	s := summaries[0]
+	if s.DOI != "10.1038/s41380-024-02456-7" {
+		t.Errorf("unexpected DOI %q", s.DOI)
+	}
+	if s.PMCID != "PMC10987654" {
+		t.Errorf("unexpected PMCID %q", s.PMCID)
+	}
+	if s.FullJournalName != "Molecular psychiatry" || s.Source != "Mol Psychiatry" {
+		t.Errorf("unexpected journal %q / %q", s.FullJournalName, s.Source)
+	}
+	if len(s.Authors) != 3 || !s.Authors[2].Collective || s.Authors[0].Collective {
+		t.Errorf("unexpected authors: %+v", s.Authors)
+	}
+	if len(s.History) != 3 || s.History[1].Status != "accepted" || s.History[1].Date != "2023-12-20" {
+		t.Errorf("unexpected history: %+v", s.History)
+	}
+	if s.Language != "eng" {
+		t.Errorf("expected language eng, got %q", s.Language)
+	}
+	if s.Year() != "2024" {
+		t.Errorf("expected year 2024, got %q", s.Year())
+	}
+}
+
+func TestDocSummary_Article(t *testing.T) {
+	data := loadTestdata(t, "esummary_pubmed.json")
+	summaries, err := parseSummaries(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	a := summaries[0].Article()
+	if a.PMID != "38123456" || a.Year != "2024" || a.Month != "Mar" {
+		t.Errorf("unexpected article basics: %+v", a)
+	}
+	if a.Journal != "Molecular psychiatry" || a.JournalAbbrev != "Mol Psychiatry" {
+		t.Errorf("unexpected journal %q / %q", a.Journal, a.JournalAbbrev)
+	}
+	if len(a.Authors) != 3 {
+		t.Fatalf("expected 3 authors, got %d", len(a.Authors))
+	}
+	if a.Authors[0].LastName != "Pedapati" || a.Authors[0].Initials != "EV" {
+		t.Errorf("unexpected first author: %+v", a.Authors[0])
+	}
+	// ESummary has no fore names; initials must not be passed off as one.
+	if a.Authors[0].ForeName != "" || a.Authors[0].FullName() != "EV Pedapati" {
+		t.Errorf("expected empty fore name with initials fallback, got %+v", a.Authors[0])
+	}
+	if a.Authors[2].CollectiveName != "FXS Biomarker Consortium" {
+		t.Errorf("expected collective author, got %+v", a.Authors[2])
+	}
+	if a.Abstract != "" || len(a.MeSHTerms) != 0 {
+		t.Error("summaries should not carry abstracts or MeSH terms")
+	}
+}
+
+func TestSummaryHistory_UsesWebEnv(t *testing.T) {
+	fixture := loadTestdata(t, "esummary_pubmed.json")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("id") != "" {
+			t.Errorf("expected no id param for history summary, got %q", q.Get("id"))
+		}
+		if got := q.Get("WebEnv"); got != "MCID_123" {
+			t.Errorf("expected WebEnv=MCID_123, got %q", got)
+		}
+		if got := q.Get("query_key"); got != "1" {
+			t.Errorf("expected query_key=1, got %q", got)
+		}
+		if got := q.Get("retstart"); got != "40" {
+			t.Errorf("expected retstart=40, got %q", got)
+		}
+		if got := q.Get("retmax"); got != "20" {
+			t.Errorf("expected retmax=20, got %q", got)
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	summaries, err := c.SummaryHistory(context.Background(), "MCID_123", "1", 40, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Errorf("expected 2 summaries, got %d", len(summaries))
+	}
+}
+
+func TestSummary_EmptyPMIDs(t *testing.T) {
+	c := NewClient(WithAPIKey("test"))
+	if _, err := c.Summary(context.Background(), nil); err == nil {
+		t.Error("expected error for empty PMIDs")
+	}
+}
+
+func TestRecordSummaries_Gene(t *testing.T) {
+	fixture := loadTestdata(t, "esummary_gene.json")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("db"); got != "gene" {
+			t.Errorf("expected db=gene, got %q", got)
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	records, err := c.RecordSummaries(context.Background(), "gene", []string{"2332", "14265", "999999999"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records (error skipped), got %d", len(records))
+	}
+	want := RecordSummary{DB: "gene", UID: "2332", Accession: "FMR1", Title: "fragile X messenger ribonucleoprotein 1", Organism: "Homo sapiens"}
+	if records[0] != want {
+		t.Errorf("expected %+v, got %+v", want, records[0])
+	}
+	if records[1].Organism != "Mus musculus" {
+		t.Errorf("expected mouse ortholog second, got %+v", records[1])
+	}
+}
+
+func TestRecordSummaries_SequenceAndPMC(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("db") {
+		case "protein":
+			w.Write([]byte(`{"result":{"uids":["4503765"],"4503765":{"uid":"4503765","caption":"NP_002015","accessionversion":"NP_002015.1","title":"FMRP isoform ISO1","organism":"Homo sapiens"}}}`))
+		case "pmc":
+			w.Write([]byte(`{"result":{"uids":["10245678"],"10245678":{"uid":"10245678","title":"A Study","articleids":[{"idtype":"pmid","value":"37286542"},{"idtype":"pmcid","value":"PMC10245678"}]}}}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithAPIKey("test"))
+	records, err := c.RecordSummaries(context.Background(), "protein", []string{"4503765"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].Accession != "NP_002015.1" || records[0].Organism != "Homo sapiens" {
+		t.Errorf("unexpected protein record: %+v", records)
+	}
+
+	records, err = c.RecordSummaries(context.Background(), "pmc", []string{"10245678"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].Accession != "PMC10245678" || records[0].Title != "A Study" {
+		t.Errorf("unexpected PMC record: %+v", records)
+	}
+}
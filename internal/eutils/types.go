@@ -4,10 +4,31 @@ package eutils
 // SearchResult represents the result of an ESearch query.
 type SearchResult struct {
 	Count            int      `json:"count"`
+	Offset           int      `json:"offset,omitempty"`
 	IDs              []string `json:"ids"`
 	QueryTranslation string   `json:"query_translation"`
 	WebEnv           string   `json:"web_env,omitempty"`
 	QueryKey         string   `json:"query_key,omitempty"`
+	SuggestedQuery   string   `json:"suggested_query,omitempty"` // ESpell correction for zero-hit queries
+}
+
+// PostResult is the history-server handle for a set of IDs uploaded via EPost.
+type PostResult struct {
+	WebEnv     string   `json:"web_env"`
+	QueryKey   string   `json:"query_key"`
+	Count      int      `json:"count"`
+	InvalidIDs []string `json:"invalid_ids,omitempty"`
+}
+
+// CitationQuery is one citation for ECitMatch. Key is an arbitrary caller
+// label echoed back to pair results with inputs; any field may be empty.
+type CitationQuery struct {
+	Journal   string // Journal title or ISO abbreviation
+	Year      string
+	Volume    string
+	FirstPage string
+	Author    string // First author, e.g. "Bear" or "Bear MF"
+	Key       string
 }
 
 // Article represents a PubMed article with parsed fields.
@@ -24,11 +45,161 @@ type Article struct {
 	Pages            string            `json:"pages,omitempty"`
 	Year             string            `json:"year"`
 	Month            string            `json:"month,omitempty"`
+	PubDate          PubDate           `json:"pub_date,omitzero"`
 	DOI              string            `json:"doi,omitempty"`
 	PMCID            string            `json:"pmcid,omitempty"`
 	MeSHTerms        []MeSHTerm        `json:"mesh_terms,omitempty"`
 	PublicationTypes []string          `json:"publication_types"`
 	Language         string            `json:"language"`
+
+	Keywords            []string            `json:"keywords,omitempty"`
+	Grants              []Grant             `json:"grants,omitempty"`
+	Chemicals           []Chemical          `json:"chemicals,omitempty"`
+	SupplementaryMeSH   []SupplementaryMeSH `json:"supplementary_mesh,omitempty"`
+	CommentsCorrections []CommentCorrection `json:"comments_corrections,omitempty"`
+	ArticleDates        []ArticleDate       `json:"article_dates,omitempty"`
+	History             []HistoryDate       `json:"history,omitempty"`
+	ELocationIDs        []ELocationID       `json:"elocation_ids,omitempty"`
+	COIStatement        string              `json:"coi_statement,omitempty"`
+	DataBanks           []DataBank          `json:"data_banks,omitempty"`
+	OtherAbstracts      []OtherAbstract     `json:"other_abstracts,omitempty"`
+
+	Corrections []Correction `json:"corrections,omitempty"` // Retractions, errata, concerns and updates
+	Retracted   bool         `json:"retracted,omitempty"`
+
+	Book *BookInfo `json:"book,omitempty"` // Set for NCBI Bookshelf records
+}
+
+// Bookshelf record types for BookInfo.Type.
+const (
+	BookTypeBook    = "book"
+	BookTypeChapter = "chapter"
+)
+
+// BookInfo describes an NCBI Bookshelf record (PubmedBookArticle): either a
+// whole book or a chapter within one. For chapters Article.Title is the
+// chapter title and Title here is the containing book.
+type BookInfo struct {
+	Type              string   `json:"type"` // BookTypeBook or BookTypeChapter
+	Title             string   `json:"title"`
+	Chapter           string   `json:"chapter,omitempty"` // Chapter location label
+	Publisher         string   `json:"publisher,omitempty"`
+	PublisherLocation string   `json:"publisher_location,omitempty"`
+	Editors           []Author `json:"editors,omitempty"`
+	Edition           string   `json:"edition,omitempty"`
+	Volume            string   `json:"volume,omitempty"`
+	Collection        string   `json:"collection,omitempty"`
+	ISBNs             []string `json:"isbns,omitempty"`
+	Accession         string   `json:"accession,omitempty"` // Bookshelf ID, e.g. NBK1384
+}
+
+// PubDate is an article's publication date as recorded by NLM. Any part
+// may be missing: MEDLINE dates can be a season ("Winter 2020") or a range
+// ("2020 Jan-Feb"), in which case MedlineDate keeps the original text and
+// Year/Month/Season hold what could be parsed from its start. Electronic is
+// the publisher's electronic publication date (YYYY-MM-DD) when known.
+type PubDate struct {
+	Year        int    `json:"year,omitempty"`
+	Month       int    `json:"month,omitempty"` // 1-12
+	Day         int    `json:"day,omitempty"`
+	Season      string `json:"season,omitempty"`
+	MedlineDate string `json:"medline_date,omitempty"`
+	Electronic  string `json:"electronic,omitempty"`
+}
+
+// Grant is a funding source from the GrantList.
+type Grant struct {
+	GrantID string `json:"grant_id,omitempty"`
+	Acronym string `json:"acronym,omitempty"`
+	Agency  string `json:"agency"`
+	Country string `json:"country,omitempty"`
+}
+
+// Chemical is a substance from the ChemicalList.
+type Chemical struct {
+	Name           string `json:"name"`
+	UI             string `json:"ui,omitempty"`
+	RegistryNumber string `json:"registry_number,omitempty"`
+}
+
+// SupplementaryMeSH is a supplementary concept record (disease, protocol,
+// organism, ...) indexed for the article.
+type SupplementaryMeSH struct {
+	Name string `json:"name"`
+	UI   string `json:"ui,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// CommentCorrection links the article to a related record: errata,
+// retractions, comments, updates and republished versions. RefType is
+// NLM's value, e.g. "ErratumIn", "RetractionIn" or "CommentOn".
+type CommentCorrection struct {
+	RefType   string `json:"ref_type"`
+	RefSource string `json:"ref_source,omitempty"`
+	PMID      string `json:"pmid,omitempty"`
+}
+
+// CorrectionType is a CommentsCorrections RefType that points to a notice
+// published against the article.
+type CorrectionType string
+
+const (
+	CorrectionRetraction          CorrectionType = "RetractionIn"
+	CorrectionErratum             CorrectionType = "ErratumIn"
+	CorrectionExpressionOfConcern CorrectionType = "ExpressionOfConcernIn"
+	CorrectionUpdate              CorrectionType = "UpdateIn"
+)
+
+// Label returns a short human-readable name for the notice type.
+func (t CorrectionType) Label() string {
+	switch t {
+	case CorrectionRetraction:
+		return "Retraction"
+	case CorrectionErratum:
+		return "Erratum"
+	case CorrectionExpressionOfConcern:
+		return "Expression of concern"
+	case CorrectionUpdate:
+		return "Update"
+	default:
+		return string(t)
+	}
+}
+
+// Correction is a retraction, erratum, expression of concern or update
+// notice for an article. PMID identifies the notice itself.
+type Correction struct {
+	Type   CorrectionType `json:"type"`
+	Source string         `json:"source,omitempty"`
+	PMID   string         `json:"pmid,omitempty"`
+}
+
+// ArticleDate is a publisher-supplied date such as the electronic
+// publication date, as YYYY-MM-DD.
+type ArticleDate struct {
+	Type string `json:"type"`
+	Date string `json:"date"`
+}
+
+// ELocationID is an electronic location (DOI or publisher item identifier).
+type ELocationID struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// DataBank lists accession numbers deposited in an external databank
+// such as ClinicalTrials.gov or GEO.
+type DataBank struct {
+	Name             string   `json:"name"`
+	AccessionNumbers []string `json:"accession_numbers,omitempty"`
+}
+
+// OtherAbstract is an additional abstract, often a translation or a
+// plain-language summary.
+type OtherAbstract struct {
+	Type     string `json:"type,omitempty"`
+	Language string `json:"language,omitempty"`
+	Text     string `json:"text"`
 }
 
 // AbstractSection represents a labeled section of a structured abstract.
@@ -39,30 +210,73 @@ type AbstractSection struct {
 
 // Author represents an article author.
 type Author struct {
-	LastName       string `json:"last_name"`
-	ForeName       string `json:"fore_name"`
-	Initials       string `json:"initials"`
-	CollectiveName string `json:"collective_name,omitempty"`
-	Affiliation    string `json:"affiliation,omitempty"`
+	LastName       string   `json:"last_name"`
+	ForeName       string   `json:"fore_name"`
+	Initials       string   `json:"initials"`
+	CollectiveName string   `json:"collective_name,omitempty"`
+	Affiliation    string   `json:"affiliation,omitempty"` // First affiliation, kept for compatibility
+	Affiliations   []string `json:"affiliations,omitempty"`
+	ORCID          string   `json:"orcid,omitempty"`
 }
 
 // FullName returns "ForeName LastName", or CollectiveName if present.
+// Initials stand in for a missing fore name, as in ESummary records.
 func (a Author) FullName() string {
 	if a.CollectiveName != "" {
 		return a.CollectiveName
 	}
-	if a.ForeName == "" {
+	fore := a.ForeName
+	if fore == "" {
+		fore = a.Initials
+	}
+	if fore == "" {
 		return a.LastName
 	}
-	return a.ForeName + " " + a.LastName
+	return fore + " " + a.LastName
 }
 
 // MeSHTerm represents a MeSH heading with optional qualifiers.
+// MajorTopic is the descriptor's own flag; a qualifier can also be major.
 type MeSHTerm struct {
-	Descriptor   string   `json:"descriptor"`
-	DescriptorUI string   `json:"descriptor_ui"`
-	MajorTopic   bool     `json:"major_topic"`
-	Qualifiers   []string `json:"qualifiers,omitempty"`
+	Descriptor   string          `json:"descriptor"`
+	DescriptorUI string          `json:"descriptor_ui"`
+	MajorTopic   bool            `json:"major_topic"`
+	Qualifiers   []MeSHQualifier `json:"qualifiers,omitempty"`
+}
+
+// MeSHQualifier is a subheading attached to a MeSH heading, such as
+// genetics (Q000235).
+type MeSHQualifier struct {
+	Name       string `json:"name"`
+	UI         string `json:"ui"`
+	MajorTopic bool   `json:"major_topic"`
+}
+
+// Major reports whether the heading is a major topic of the article,
+// through the descriptor or any of its qualifiers.
+func (m MeSHTerm) Major() bool {
+	if m.MajorTopic {
+		return true
+	}
+	for _, q := range m.Qualifiers {
+		if q.MajorTopic {
+			return true
+		}
+	}
+	return false
+}
+
+// QualifierNames returns the qualifier names, with major topics marked by
+// a trailing *.
+func (m MeSHTerm) QualifierNames() []string {
+	names := make([]string, len(m.Qualifiers))
+	for i, q := range m.Qualifiers {
+		names[i] = q.Name
+		if q.MajorTopic {
+			names[i] += "*"
+		}
+	}
+	return names
 }
 
 // LinkResult represents the result of an ELink query.
@@ -77,10 +291,142 @@ type LinkItem struct {
 	Score int    `json:"score,omitempty"`
 }
 
+// AggregatedLink is a linked article with the source PMIDs that link to it.
+type AggregatedLink struct {
+	ID      string   `json:"id"`
+	Count   int      `json:"count"`
+	Sources []string `json:"sources"`
+}
+
+// LinkSet holds the ELink results for one source ID, with one LinkSetDB
+// per link name.
+type LinkSet struct {
+	SourceID   string      `json:"source_id"`
+	DbFrom     string      `json:"dbfrom"`
+	LinkSetDBs []LinkSetDB `json:"linksetdbs"`
+}
+
+// LinkSetDB is the list of records in DbTo reached through one link name.
+type LinkSetDB struct {
+	DbTo     string     `json:"dbto"`
+	LinkName string     `json:"linkname"`
+	Links    []LinkItem `json:"links"`
+}
+
+// IDs returns the linked IDs across all link names, without duplicates.
+func (s LinkSet) IDs() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, db := range s.LinkSetDBs {
+		for _, l := range db.Links {
+			if !seen[l.ID] {
+				seen[l.ID] = true
+				ids = append(ids, l.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// RecordSummary is a database-independent ESummary record, used to label
+// records linked from PubMed into other Entrez databases.
+type RecordSummary struct {
+	DB        string `json:"db"`
+	UID       string `json:"uid"`
+	Accession string `json:"accession,omitempty"` // Gene symbol, sequence accession, PMCID, VCV or GSE accession
+	Title     string `json:"title"`
+	Organism  string `json:"organism,omitempty"`
+}
+
 // SearchOptions configures a search query.
 type SearchOptions struct {
-	Limit   int    `json:"limit,omitempty"`
-	Sort    string `json:"sort,omitempty"`
-	MinDate string `json:"min_date,omitempty"`
-	MaxDate string `json:"max_date,omitempty"`
+	Limit    int    `json:"limit,omitempty"`
+	RetStart int    `json:"retstart,omitempty"` // Zero-based offset into the result set
+	Sort     string `json:"sort,omitempty"`
+	MinDate  string `json:"min_date,omitempty"`
+	MaxDate  string `json:"max_date,omitempty"`
+	WebEnv   string `json:"web_env,omitempty"` // Existing history session; queries may reference its sets as #<query_key>
+}
+
+// BatchOptions configures a chunked fetch.
+type BatchOptions struct {
+	BatchSize   int // PMIDs per request (default DefaultBatchSize)
+	Concurrency int // Chunks in flight at once (default DefaultBatchConcurrency)
+}
+
+// BatchResult holds the articles returned by a chunked fetch, in request
+// order, plus any requested PMIDs that NCBI did not return. PMIDs NCBI
+// reported as DeleteCitation entries are listed in DeletedIDs instead of
+// MissingIDs.
+type BatchResult struct {
+	Articles   []Article `json:"articles"`
+	MissingIDs []string  `json:"missing_ids,omitempty"`
+	DeletedIDs []string  `json:"deleted_ids,omitempty"`
+}
+
+// DocSummary is a lightweight article record from ESummary. It carries
+// enough metadata for tables and citations but no abstract or MeSH terms.
+type DocSummary struct {
+	PMID            string          `json:"pmid"`
+	Title           string          `json:"title"`
+	Authors         []SummaryAuthor `json:"authors"`
+	Source          string          `json:"source"`
+	FullJournalName string          `json:"full_journal_name,omitempty"`
+	PubDate         string          `json:"pub_date"`
+	EPubDate        string          `json:"epub_date,omitempty"`
+	Volume          string          `json:"volume,omitempty"`
+	Issue           string          `json:"issue,omitempty"`
+	Pages           string          `json:"pages,omitempty"`
+	ELocationID     string          `json:"elocation_id,omitempty"`
+	PubTypes        []string        `json:"pub_types"`
+	Language        string          `json:"language,omitempty"`
+	DOI             string          `json:"doi,omitempty"`
+	PMCID           string          `json:"pmcid,omitempty"`
+	History         []HistoryDate   `json:"history,omitempty"`
+}
+
+// SummaryAuthor is an author as listed in ESummary ("LastName Initials").
+type SummaryAuthor struct {
+	Name       string `json:"name"`
+	Collective bool   `json:"collective,omitempty"`
+}
+
+// HistoryDate records when an article reached a publication status
+// (e.g., received, accepted, pubmed, entrez).
+type HistoryDate struct {
+	Status string `json:"status"`
+	Date   string `json:"date"`
+}
+
+// DBInfo describes an Entrez database as reported by EInfo.
+type DBInfo struct {
+	Name        string      `json:"name"`
+	MenuName    string      `json:"menu_name"`
+	Description string      `json:"description"`
+	Build       string      `json:"build,omitempty"`
+	Count       int         `json:"count"`
+	LastUpdate  string      `json:"last_update"`
+	Fields      []FieldInfo `json:"fields"`
+	Links       []LinkInfo  `json:"links"`
+}
+
+// FieldInfo describes a searchable field; Name is the tag used in queries
+// (e.g. "TIAB" for [tiab]).
+type FieldInfo struct {
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	TermCount   int    `json:"term_count"`
+	IsDate      bool   `json:"is_date,omitempty"`
+	IsNumerical bool   `json:"is_numerical,omitempty"`
+	Hierarchy   bool   `json:"hierarchy,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty"`
+}
+
+// LinkInfo describes an ELink link name available from a database.
+type LinkInfo struct {
+	Name        string `json:"name"`
+	Menu        string `json:"menu"`
+	Description string `json:"description"`
+	DbTo        string `json:"db_to"`
 }
@@ -0,0 +1,350 @@
+// Package idconv maps between PMIDs, PMCIDs, DOIs and NIH Manuscript IDs
+// using the NCBI PMC ID Converter API, falling back to E-utilities for
+// articles the converter does not know (it covers only PMC content).
+package idconv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/ncbi"
+)
+
+const (
+	// DefaultBaseURL is the NCBI PMC ID Converter API base URL.
+	DefaultBaseURL = "https://pmc.ncbi.nlm.nih.gov/tools/idconv/api/v1"
+
+	// MaxIDsPerRequest is the converter's limit on IDs per request.
+	MaxIDsPerRequest = 200
+)
+
+// IDType is a kind of article identifier accepted by the converter.
+type IDType string
+
+const (
+	TypePMID  IDType = "pmid"
+	TypePMCID IDType = "pmcid"
+	TypeDOI   IDType = "doi"
+	TypeMID   IDType = "mid" // NIH Manuscript ID, e.g. NIHMS311352
+)
+
+// Record sources.
+const (
+	SourceIDConv   = "idconv"
+	SourceESearch  = "esearch"
+	SourceESummary = "esummary"
+)
+
+// Record is the set of identifiers for one requested ID. Source tells
+// where the mapping came from; Error is set when no mapping was found.
+type Record struct {
+	Input  string `json:"input"`
+	Type   IDType `json:"type,omitempty"`
+	PMID   string `json:"pmid,omitempty"`
+	PMCID  string `json:"pmcid,omitempty"`
+	DOI    string `json:"doi,omitempty"`
+	MID    string `json:"mid,omitempty"`
+	Source string `json:"source,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Client converts article identifiers. It shares the NCBI base client's
+// rate limiter, retry policy and cache for both the converter and the
+// E-utilities fallback.
+type Client struct {
+	conv   *ncbi.BaseClient
+	eutils *eutils.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL points the converter at a different API base URL, such as an
+// httptest server.
+func WithBaseURL(u string) Option {
+	return func(c *Client) { c.conv.BaseURL = u }
+}
+
+// WithoutFallback disables E-utilities lookups for IDs the converter
+// cannot map.
+func WithoutFallback() Option {
+	return func(c *Client) { c.eutils = nil }
+}
+
+// NewClient creates a converter client from an existing NCBI base client.
+// E-utilities fallbacks go to base's URL.
+func NewClient(base *ncbi.BaseClient, opts ...Option) *Client {
+	conv := *base
+	conv.BaseURL = DefaultBaseURL
+	conv.APIKey = "" // the converter does not take E-utilities keys
+	c := &Client{conv: &conv, eutils: eutils.NewClientWithBase(base)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+var (
+	doiRe = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+	midRe = regexp.MustCompile(`^[A-Z]{2,}\d+$`)
+)
+
+// Normalize returns id in canonical form along with its detected type, or
+// an empty type when the ID is not recognized. PMCIDs get an upper-case
+// "PMC" prefix and DOIs lose any "doi:" or resolver URL prefix.
+func Normalize(id string) (string, IDType) {
+	id = strings.TrimSpace(id)
+	lower := strings.ToLower(id)
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		if strings.HasPrefix(lower, prefix) {
+			id = strings.TrimSpace(id[len(prefix):])
+			lower = strings.ToLower(id)
+			break
+		}
+	}
+
+	switch {
+	case id == "":
+		return "", ""
+	case isDigits(id):
+		return id, TypePMID
+	case strings.HasPrefix(lower, "pmc") && isDigits(id[3:]):
+		return "PMC" + id[3:], TypePMCID
+	case doiRe.MatchString(id):
+		return id, TypeDOI
+	case midRe.MatchString(strings.ToUpper(id)):
+		return strings.ToUpper(id), TypeMID
+	}
+	return id, ""
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// flexString decodes a JSON string or number; the converter has returned
+// PMIDs as both.
+type flexString string
+
+func (f *flexString) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*f = flexString(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*f = flexString(n.String())
+	return nil
+}
+
+type convResponse struct {
+	Status  string       `json:"status"`
+	Message string       `json:"message"`
+	Records []convRecord `json:"records"`
+}
+
+type convRecord struct {
+	RequestedID flexString `json:"requested-id"`
+	PMID        flexString `json:"pmid"`
+	PMCID       string     `json:"pmcid"`
+	DOI         string     `json:"doi"`
+	MID         string     `json:"mid"`
+	Status      string     `json:"status"`
+	ErrMsg      string     `json:"errmsg"`
+}
+
+// Convert maps each ID to its other identifiers. IDs may be of mixed types;
+// the result is parallel to ids. Unrecognized IDs and IDs nobody could map
+// are reported in Record.Error rather than failing the whole batch; an
+// error is returned only when a converter request fails. Repeated IDs are
+// looked up once and share the result.
+func (c *Client) Convert(ctx context.Context, ids []string) ([]Record, error) {
+	// records holds one entry per distinct ID; at maps each input to it.
+	var records []Record
+	at := make([]int, len(ids))
+	seen := make(map[string]int)
+	byType := make(map[IDType][]int)
+	var order []IDType
+	for n, raw := range ids {
+		id, typ := Normalize(raw)
+		rec := Record{Input: strings.TrimSpace(raw), Type: typ}
+		if typ == "" {
+			rec.Error = "unrecognized identifier"
+		} else {
+			rec.Input = id
+		}
+		key := string(typ) + ":" + strings.ToLower(rec.Input)
+		i, ok := seen[key]
+		if !ok {
+			i = len(records)
+			seen[key] = i
+			records = append(records, rec)
+			if typ != "" {
+				if _, ok := byType[typ]; !ok {
+					order = append(order, typ)
+				}
+				byType[typ] = append(byType[typ], i)
+			}
+		}
+		at[n] = i
+	}
+
+	for _, typ := range order {
+		idx := byType[typ]
+		for start := 0; start < len(idx); start += MaxIDsPerRequest {
+			chunk := idx[start:min(start+MaxIDsPerRequest, len(idx))]
+			if err := c.convertChunk(ctx, typ, records, chunk); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if c.eutils != nil {
+		c.fallbackDOI(ctx, records)
+		c.fallbackSummary(ctx, records)
+	}
+
+	out := make([]Record, len(ids))
+	for n, i := range at {
+		out[n] = records[i]
+	}
+	return out, nil
+}
+
+func (c *Client) convertChunk(ctx context.Context, typ IDType, records []Record, idx []int) error {
+	ids := make([]string, len(idx))
+	pending := make(map[string]int, len(idx))
+	for j, i := range idx {
+		ids[j] = records[i].Input
+		pending[strings.ToLower(records[i].Input)] = i
+	}
+
+	params := url.Values{}
+	params.Set("ids", strings.Join(ids, ","))
+	params.Set("idtype", string(typ))
+	params.Set("format", "json")
+	params.Set("versions", "no")
+
+	body, err := c.conv.DoGet(ctx, "articles/", params)
+	if err != nil {
+		return fmt.Errorf("ID converter request failed: %w", err)
+	}
+	var resp convResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("parsing ID converter response: %w", err)
+	}
+	if resp.Status == "error" {
+		return fmt.Errorf("ID converter error: %s", resp.Message)
+	}
+
+	for j, rec := range resp.Records {
+		i, ok := pending[strings.ToLower(string(rec.RequestedID))]
+		if !ok {
+			// Older responses omit requested-id; records follow request order.
+			if rec.RequestedID != "" || j >= len(idx) {
+				continue
+			}
+			i = idx[j]
+		}
+		r := &records[i]
+		if rec.Status == "error" {
+			r.Error = rec.ErrMsg
+			if r.Error == "" {
+				r.Error = "not found"
+			}
+			continue
+		}
+		r.PMID = string(rec.PMID)
+		r.PMCID = rec.PMCID
+		r.DOI = rec.DOI
+		r.MID = rec.MID
+		r.Source = SourceIDConv
+		r.Error = ""
+	}
+
+	for _, i := range idx {
+		if records[i].Source == "" && records[i].Error == "" {
+			records[i].Error = "not found"
+		}
+	}
+	return nil
+}
+
+// fallbackDOI looks up unmapped DOIs with an ESearch [doi] query, as the
+// reference resolver does.
+func (c *Client) fallbackDOI(ctx context.Context, records []Record) {
+	for i := range records {
+		r := &records[i]
+		if r.Type != TypeDOI || r.PMID != "" {
+			continue
+		}
+		result, err := c.eutils.Search(ctx, r.Input+"[doi]", &eutils.SearchOptions{Limit: 1})
+		if err != nil || len(result.IDs) == 0 {
+			continue
+		}
+		r.PMID = result.IDs[0]
+		r.DOI = r.Input
+		r.Source = SourceESearch
+		r.Error = ""
+	}
+}
+
+// fallbackSummary fills in DOIs and PMCIDs for records that have a PMID
+// (given or found by ESearch) but were not mapped by the converter.
+func (c *Client) fallbackSummary(ctx context.Context, records []Record) {
+	var pmids []string
+	need := make(map[string][]int)
+	for i, r := range records {
+		pmid := r.PMID
+		if pmid == "" && r.Type == TypePMID {
+			pmid = r.Input
+		}
+		if pmid == "" || r.Source == SourceIDConv {
+			continue
+		}
+		if _, ok := need[pmid]; !ok {
+			pmids = append(pmids, pmid)
+		}
+		need[pmid] = append(need[pmid], i)
+	}
+	if len(pmids) == 0 {
+		return
+	}
+
+	summaries, err := c.eutils.Summary(ctx, pmids)
+	if err != nil {
+		return
+	}
+	for _, s := range summaries {
+		for _, i := range need[s.PMID] {
+			r := &records[i]
+			r.PMID = s.PMID
+			if r.DOI == "" {
+				r.DOI = s.DOI
+			}
+			if r.PMCID == "" {
+				r.PMCID = s.PMCID
+			}
+			if r.Source == "" {
+				r.Source = SourceESummary
+			}
+			r.Error = ""
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package idconv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/ncbi"
+)
+
+func loadTestdata(t *testing.T, filename string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", "testdata", filename))
+	if err != nil {
+		t.Fatalf("failed to load testdata/%s: %v", filename, err)
+	}
+	return data
+}
+
+func newTestClient(srv *httptest.Server, opts ...Option) *Client {
+	base := ncbi.NewBaseClient(ncbi.WithBaseURL(srv.URL), ncbi.WithAPIKey("test"))
+	return NewClient(base, append([]Option{WithBaseURL(srv.URL)}, opts...)...)
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in       string
+		wantID   string
+		wantType IDType
+	}{
+		{"37286542", "37286542", TypePMID},
+		{" pmc10245678 ", "PMC10245678", TypePMCID},
+		{"10.1186/s13229-023-00555-1", "10.1186/s13229-023-00555-1", TypeDOI},
+		{"doi:10.1186/s13229-023-00555-1", "10.1186/s13229-023-00555-1", TypeDOI},
+		{"https://doi.org/10.1186/ABC", "10.1186/ABC", TypeDOI},
+		{"nihms311352", "NIHMS311352", TypeMID},
+		{"not an id", "not an id", ""},
+		{"PMC", "PMC", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		id, typ := Normalize(tt.in)
+		if id != tt.wantID || typ != tt.wantType {
+			t.Errorf("Normalize(%q) = %q, %q; want %q, %q", tt.in, id, typ, tt.wantID, tt.wantType)
+		}
+	}
+}
+
+func TestConvert_MixedWithFallback(t *testing.T) {
+	summary := loadTestdata(t, "esummary_pubmed.json")
+	var convRequests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/articles/"):
+			atomic.AddInt32(&convRequests, 1)
+			if q.Get("format") != "json" || q.Get("api_key") != "" || q.Get("tool") == "" {
+				t.Errorf("unexpected converter params: %v", q)
+			}
+			switch q.Get("idtype") {
+			case "pmcid":
+				fmt.Fprint(w, `{"status":"ok","records":[{"requested-id":"PMC10245678","pmcid":"PMC10245678","pmid":37286542,"doi":"10.1186/s13229-023-00555-1"}]}`)
+			case "pmid":
+				if q.Get("ids") != "37286542,38123456" {
+					t.Errorf("expected PMIDs batched together, got %q", q.Get("ids"))
+				}
+				fmt.Fprint(w, `{"status":"ok","records":[
+					{"requested-id":"37286542","pmcid":"PMC10245678","pmid":"37286542","doi":"10.1186/s13229-023-00555-1","mid":"NIHMS1900001"},
+					{"requested-id":"38123456","pmid":"38123456","status":"error","errmsg":"invalid article id"}]}`)
+			case "doi":
+				fmt.Fprint(w, `{"status":"ok","records":[{"requested-id":"10.1523/JNEUROSCI.1234-22.2023","status":"error","errmsg":"Identifier not found in PMC"}]}`)
+			default:
+				t.Errorf("unexpected idtype %q", q.Get("idtype"))
+			}
+		case strings.HasSuffix(r.URL.Path, "/esearch.fcgi"):
+			if q.Get("term") != "10.1523/JNEUROSCI.1234-22.2023[doi]" {
+				t.Errorf("unexpected fallback query %q", q.Get("term"))
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"esearchresult": map[string]any{"count": "1", "idlist": []string{"35999876"}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/esummary.fcgi"):
+			w.Write(summary)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv)
+	ids := []string{"PMC10245678", "37286542", "doi:10.1523/JNEUROSCI.1234-22.2023", "38123456", "bogus!"}
+	records, err := c.Convert(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != len(ids) {
+		t.Fatalf("expected %d records, got %d", len(ids), len(records))
+	}
+
+	if r := records[0]; r.PMID != "37286542" || r.DOI != "10.1186/s13229-023-00555-1" || r.Source != SourceIDConv {
+		t.Errorf("unexpected PMCID record: %+v", r)
+	}
+	if r := records[1]; r.PMCID != "PMC10245678" || r.MID != "NIHMS1900001" || r.Type != TypePMID {
+		t.Errorf("unexpected PMID record: %+v", r)
+	}
+	if r := records[2]; r.Input != "10.1523/JNEUROSCI.1234-22.2023" || r.PMID != "35999876" || r.Source != SourceESearch || r.Error != "" {
+		t.Errorf("expected DOI resolved by ESearch, got %+v", r)
+	}
+	if r := records[3]; r.DOI != "10.1038/s41380-024-02456-7" || r.PMCID != "PMC10987654" || r.Source != SourceESummary || r.Error != "" {
+		t.Errorf("expected PMID filled from ESummary, got %+v", r)
+	}
+	if r := records[4]; r.Error != "unrecognized identifier" || r.Type != "" {
+		t.Errorf("expected unrecognized ID error, got %+v", r)
+	}
+	if n := atomic.LoadInt32(&convRequests); n != 3 {
+		t.Errorf("expected one converter request per ID type, got %d", n)
+	}
+}
+
+func TestConvert_ChunksAndNoFallback(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/articles/") {
+			t.Errorf("unexpected fallback request to %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		if len(ids) > MaxIDsPerRequest {
+			t.Errorf("expected at most %d IDs per request, got %d", MaxIDsPerRequest, len(ids))
+		}
+		// No requested-id: records follow request order.
+		var recs []string
+		for _, id := range ids {
+			if id == "7" {
+				recs = append(recs, `{"pmid":"7","status":"error","errmsg":"invalid article id"}`)
+				continue
+			}
+			recs = append(recs, fmt.Sprintf(`{"pmid":%q,"pmcid":"PMC%s"}`, id, id))
+		}
+		fmt.Fprintf(w, `{"status":"ok","records":[%s]}`, strings.Join(recs, ","))
+	}))
+	defer srv.Close()
+
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i + 1)
+	}
+	records, err := newTestClient(srv, WithoutFallback()).Convert(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected 2 converter requests, got %d", n)
+	}
+	if records[249].PMCID != "PMC250" {
+		t.Errorf("unexpected last record: %+v", records[249])
+	}
+	if records[6].Error != "invalid article id" || records[6].Source != "" {
+		t.Errorf("expected unmapped PMID error without fallback, got %+v", records[6])
+	}
+}
+
+func TestConvert_Duplicates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ids"); got != "PMC123,PMC456" {
+			t.Errorf("expected each ID once, got %q", got)
+		}
+		fmt.Fprint(w, `{"status":"ok","records":[`+
+			`{"requested-id":"PMC123","pmcid":"PMC123","pmid":"111"},`+
+			`{"requested-id":"PMC456","pmcid":"PMC456","pmid":"222"}]}`)
+	}))
+	defer srv.Close()
+
+	ids := []string{"PMC123", "PMC456", "pmc123", "bogus", "bogus"}
+	records, err := newTestClient(srv, WithoutFallback()).Convert(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != len(ids) {
+		t.Fatalf("expected %d records, got %d", len(ids), len(records))
+	}
+	for _, i := range []int{0, 2} {
+		if records[i].PMID != "111" || records[i].Error != "" {
+			t.Errorf("record %d: expected PMID 111, got %+v", i, records[i])
+		}
+	}
+	if records[1].PMID != "222" || records[4].Error != "unrecognized identifier" {
+		t.Errorf("unexpected records %+v", records)
+	}
+}
+
+func TestConvert_ServiceError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"error","message":"Invalid idtype"}`)
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(srv).Convert(context.Background(), []string{"123"})
+	if err == nil || !strings.Contains(err.Error(), "Invalid idtype") {
+		t.Errorf("expected converter error, got %v", err)
+	}
+}
@@ -0,0 +1,277 @@
+package mesh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ExpandOptions controls how ExpandQuery rewrites free-text terms.
+type ExpandOptions struct {
+	// NoExplode emits [mh:noexp] so narrower descriptors are not included.
+	NoExplode bool
+}
+
+// Mapping records what ExpandQuery did with one free-text term. Either
+// Descriptor and Expansion are set, or Reason says why the term was kept.
+type Mapping struct {
+	Term       string   `json:"term"`
+	Descriptor string   `json:"descriptor,omitempty"`
+	UI         string   `json:"ui,omitempty"`
+	EntryTerms []string `json:"entry_terms,omitempty"`
+	Expansion  string   `json:"expansion,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+}
+
+// Mapped reports whether the term was replaced.
+func (m Mapping) Mapped() bool {
+	return m.Expansion != ""
+}
+
+// Expansion is a PubMed query with its free-text terms rewritten as MeSH
+// headings plus title/abstract synonyms.
+type Expansion struct {
+	Original string    `json:"original"`
+	Query    string    `json:"query"`
+	Mappings []Mapping `json:"mappings"`
+}
+
+// maxPhraseWords caps the phrases tried when an unmapped run of words is
+// split; longer MeSH headings are rare.
+const maxPhraseWords = 6
+
+// ExpandQuery maps each free-text term in query to a MeSH descriptor and
+// rewrites it as ("Descriptor"[mh] OR "synonym"[tiab] ...). Operators,
+// parentheses, field-tagged terms, history references (#1), and truncated
+// terms (autis*) are left as written. A term is only mapped when it is the
+// descriptor's name or one of its entry terms; the closest descriptor from
+// a broader NCBI search is reported but not used. An unquoted run of words
+// that does not map as a whole (fragile x syndrome autism, which PubMed
+// reads as an implicit AND) is split into the longest phrases that do.
+func (c *Client) ExpandQuery(ctx context.Context, query string, opts ExpandOptions) (*Expansion, error) {
+	exp := &Expansion{Original: query, Query: query}
+
+	var terms []queryTerm
+	for _, t := range freeTextTerms(query) {
+		ms, ts, err := c.mapRun(ctx, t, opts)
+		if err != nil {
+			return nil, err
+		}
+		exp.Mappings = append(exp.Mappings, ms...)
+		terms = append(terms, ts...)
+	}
+
+	// Replace from the end so earlier offsets stay valid.
+	for i := len(terms) - 1; i >= 0; i-- {
+		if m, t := exp.Mappings[i], terms[i]; m.Mapped() {
+			exp.Query = exp.Query[:t.start] + m.Expansion + exp.Query[t.end:]
+		}
+	}
+	return exp, nil
+}
+
+// mapRun maps t, splitting a run of words that does not map as a whole
+// into the longest phrases that do, from the left. It returns one mapping
+// per resulting term, or t's own mapping when no phrase maps.
+func (c *Client) mapRun(ctx context.Context, t queryTerm, opts ExpandOptions) ([]Mapping, []queryTerm, error) {
+	m, err := c.mapTerm(ctx, t.text, opts)
+	if err != nil || m.Mapped() || len(t.words) < 2 {
+		return []Mapping{m}, []queryTerm{t}, err
+	}
+
+	var (
+		mappings []Mapping
+		terms    []queryTerm
+		mapped   bool
+	)
+	for i := 0; i < len(t.words); {
+		j := min(len(t.words), i+maxPhraseWords)
+		for ; j > i; j-- {
+			if i == 0 && j == len(t.words) {
+				continue // the whole run, already tried
+			}
+			phrase := t.phrase(i, j)
+			pm, err := c.mapTerm(ctx, phrase.text, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+			if pm.Mapped() || j == i+1 {
+				mapped = mapped || pm.Mapped()
+				mappings = append(mappings, pm)
+				terms = append(terms, phrase)
+				break
+			}
+		}
+		i = j
+	}
+	if !mapped {
+		return []Mapping{m}, []queryTerm{t}, nil
+	}
+	return mappings, terms, nil
+}
+
+func (c *Client) mapTerm(ctx context.Context, term string, opts ExpandOptions) (Mapping, error) {
+	m := Mapping{Term: term}
+	if strings.Contains(term, "*") {
+		m.Reason = "truncated term"
+		return m, nil
+	}
+
+	cand, err := c.topDescriptor(ctx, term)
+	if errors.Is(err, ErrNotFound) {
+		m.Reason = "no MeSH descriptor"
+		return m, nil
+	}
+	if err != nil {
+		return m, fmt.Errorf("mapping %q to MeSH: %w", term, err)
+	}
+	record := &cand.MeSHRecord
+	if !cand.Exact() {
+		m.Reason = fmt.Sprintf("closest descriptor %q does not list it as an entry term", record.Name)
+		return m, nil
+	}
+
+	m.Descriptor = record.Name
+	m.UI = record.UI
+	tag := "[mh]"
+	if opts.NoExplode {
+		tag = "[mh:noexp]"
+	}
+	parts := []string{quoteTerm(record.Name) + tag}
+	seen := make(map[string]bool)
+	for _, syn := range append([]string{record.Name, term}, record.EntryTerms...) {
+		// Inverted forms such as "Syndrome, Fragile X" never occur in text.
+		key := strings.ToLower(syn)
+		if seen[key] || strings.Contains(syn, ", ") {
+			continue
+		}
+		seen[key] = true
+		if !strings.EqualFold(syn, record.Name) && !strings.EqualFold(syn, term) {
+			m.EntryTerms = append(m.EntryTerms, syn)
+		}
+		parts = append(parts, quoteTerm(syn)+"[tiab]")
+	}
+	m.Expansion = "(" + strings.Join(parts, " OR ") + ")"
+	return m, nil
+}
+
+// matches reports whether term is the record's name or an entry term.
+func (r *MeSHRecord) matches(term string) bool {
+	term = strings.TrimSpace(term)
+	if strings.EqualFold(r.Name, term) || strings.EqualFold(r.UI, term) {
+		return true
+	}
+	for _, et := range r.EntryTerms {
+		if strings.EqualFold(et, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteTerm double-quotes a phrase for PubMed, dropping embedded quotes.
+func quoteTerm(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "") + `"`
+}
+
+// queryTerm is a free-text term and its byte span in the query. Unquoted
+// runs also keep their words, so they can be split.
+type queryTerm struct {
+	text       string
+	start, end int
+	words      []queryTerm
+}
+
+// phrase returns the term made of words i through j-1.
+func (t queryTerm) phrase(i, j int) queryTerm {
+	texts := make([]string, j-i)
+	for k, w := range t.words[i:j] {
+		texts[k] = w.text
+	}
+	return queryTerm{
+		text:  strings.Join(texts, " "),
+		start: t.words[i].start,
+		end:   t.words[j-1].end,
+		words: t.words[i:j],
+	}
+}
+
+// freeTextTerms splits a PubMed query into tokens and returns its free-text
+// terms: runs of untagged words between operators and parentheses, and
+// untagged quoted phrases. Words directly before a tagged token belong to
+// it (fragile x[tiab]) and are not returned.
+func freeTextTerms(query string) []queryTerm {
+	var terms []queryTerm
+	var words []queryTerm
+	flush := func() {
+		if len(words) > 0 {
+			run := queryTerm{words: words}
+			terms = append(terms, run.phrase(0, len(words)))
+		}
+		words = nil
+	}
+
+	for i := 0; i < len(query); {
+		switch ch := query[i]; {
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			i++
+		case ch == '(' || ch == ')':
+			flush()
+			i++
+		case ch == '"':
+			flush()
+			end := strings.IndexByte(query[i+1:], '"')
+			if end < 0 {
+				return terms // unbalanced quote: leave the rest alone
+			}
+			end += i + 2
+			if tagEnd := tagAt(query, end); tagEnd > end {
+				i = tagEnd
+				continue
+			}
+			if phrase := strings.TrimSpace(query[i+1 : end-1]); phrase != "" {
+				terms = append(terms, queryTerm{text: phrase, start: i, end: end})
+			}
+			i = end
+		default:
+			start := i
+			for i < len(query) && !strings.ContainsRune(" \t\n()\"[", rune(query[i])) {
+				i++
+			}
+			word := query[start:i]
+			if tagEnd := tagAt(query, i); tagEnd > i {
+				words = nil // the tag applies to the words before it
+				i = tagEnd
+				continue
+			}
+			if word == "" {
+				i++ // stray '['
+				continue
+			}
+			switch {
+			case word == "AND" || word == "OR" || word == "NOT":
+				flush()
+			case strings.HasPrefix(word, "#"):
+				flush()
+			default:
+				words = append(words, queryTerm{text: word, start: start, end: i})
+			}
+		}
+	}
+	flush()
+	return terms
+}
+
+// tagAt returns the offset just past a field tag ([tiab], [MeSH Terms])
+// starting at i, or i when there is none.
+func tagAt(query string, i int) int {
+	if i >= len(query) || query[i] != '[' {
+		return i
+	}
+	end := strings.IndexByte(query[i:], ']')
+	if end < 0 {
+		return len(query)
+	}
+	return i + end + 1
+}
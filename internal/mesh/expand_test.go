@@ -0,0 +1,157 @@
+package mesh
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFreeTextTerms(t *testing.T) {
+	tests := map[string][]string{
+		"fragile x syndrome":                              {"fragile x syndrome"},
+		"autism AND (sleep OR melatonin)":                 {"autism", "sleep", "melatonin"},
+		`autism[mh] AND "sleep disorders" NOT review[pt]`: {"sleep disorders"},
+		`"autism"[MeSH Terms] AND fragile x[tiab]`:        nil,
+		"#1 AND autis* AND asperger syndrome":             {"autis*", "asperger syndrome"},
+		`unbalanced "quote AND x`:                         {"unbalanced"},
+	}
+	for query, want := range tests {
+		terms := freeTextTerms(query)
+		var got []string
+		for _, term := range terms {
+			got = append(got, term.text)
+			if span := strings.Trim(query[term.start:term.end], `"`); span != term.text {
+				t.Errorf("%q: span %q does not match term %q", query, span, term.text)
+			}
+		}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("freeTextTerms(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestExpandQuery(t *testing.T) {
+	c := NewClient(nil, WithStore(loadTestStore(t)))
+
+	exp, err := c.ExpandQuery(context.Background(), `fxs AND (autism OR "sleep") AND review[pt]`, ExpandOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `("Fragile X Syndrome"[mh] OR "Fragile X Syndrome"[tiab] OR "fxs"[tiab] OR "Fra(X) Syndrome"[tiab] OR ` +
+		`"Martin-Bell Syndrome"[tiab] OR "Fragile X Mental Retardation Syndrome"[tiab]) AND ` +
+		`(("Autistic Disorder"[mh] OR "Autistic Disorder"[tiab] OR "autism"[tiab] OR "Kanner's Syndrome"[tiab] OR "Early Infantile Autism"[tiab]) OR "sleep") AND review[pt]`
+	if exp.Query != want {
+		t.Errorf("unexpected query:\n got  %s\n want %s", exp.Query, want)
+	}
+	if len(exp.Mappings) != 3 {
+		t.Fatalf("expected 3 mappings, got %+v", exp.Mappings)
+	}
+	if m := exp.Mappings[0]; !m.Mapped() || m.UI != "D005600" || len(m.EntryTerms) != 3 {
+		t.Errorf("unexpected FXS mapping %+v", m)
+	}
+	if m := exp.Mappings[2]; m.Mapped() || m.Term != "sleep" || m.Reason != "no MeSH descriptor" {
+		t.Errorf("expected unmapped sleep, got %+v", m)
+	}
+
+	exp, err = c.ExpandQuery(context.Background(), "asperger syndrome", ExpandOptions{NoExplode: true})
+	if err != nil || !strings.HasPrefix(exp.Query, `("Asperger Syndrome"[mh:noexp] OR`) {
+		t.Errorf("expected [mh:noexp], got %q, %v", exp.Query, err)
+	}
+}
+
+func TestExpandQuery_OnlineRejectsLooseMatch(t *testing.T) {
+	summary := loadTestdata(t, "mesh_esummary.json")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/esummary.fcgi" {
+			w.Write(summary)
+			return
+		}
+		// The quoted [MeSH Terms] search misses; the broad search hits.
+		if strings.Contains(r.URL.Query().Get("term"), "[MeSH Terms]") {
+			w.Write([]byte(`{"esearchresult":{"count":"0","idlist":[]}}`))
+			return
+		}
+		w.Write([]byte(`{"esearchresult":{"count":"1","idlist":["68005600"]}}`))
+	}))
+	defer srv.Close()
+
+	exp, err := newTestClient(t, srv.URL).ExpandQuery(context.Background(), "fragile x", ExpandOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exp.Query != "fragile x" || !strings.Contains(exp.Mappings[0].Reason, `"Fragile X Syndrome"`) {
+		t.Errorf("expected loose match to be reported and skipped, got %+v", exp)
+	}
+}
+
+func TestExpandQuery_LookupError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(t, srv.URL).ExpandQuery(context.Background(), "autism", ExpandOptions{})
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected lookup failure to be returned, got %v", err)
+	}
+}
+
+func TestExpandQuery_SplitsUnmappedRuns(t *testing.T) {
+	c := NewClient(nil, WithStore(loadTestStore(t)))
+	ctx := context.Background()
+
+	exp, err := c.ExpandQuery(ctx, "fragile x syndrome autism", ExpandOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(exp.Mappings) != 2 || exp.Mappings[0].UI != "D005600" || exp.Mappings[1].Descriptor != "Autistic Disorder" {
+		t.Fatalf("expected the run split into two descriptors, got %+v", exp.Mappings)
+	}
+	if !strings.HasPrefix(exp.Query, `("Fragile X Syndrome"[mh] OR`) || !strings.Contains(exp.Query, `) ("Autistic Disorder"[mh] OR`) {
+		t.Errorf("unexpected query %s", exp.Query)
+	}
+
+	exp, err = c.ExpandQuery(ctx, "fxs sleep", ExpandOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(exp.Mappings) != 2 || !exp.Mappings[0].Mapped() || exp.Mappings[1].Term != "sleep" || exp.Mappings[1].Mapped() {
+		t.Errorf("expected fxs mapped and sleep kept, got %+v", exp.Mappings)
+	}
+	if !strings.HasSuffix(exp.Query, `) sleep`) {
+		t.Errorf("expected sleep left as written, got %s", exp.Query)
+	}
+
+	// Nothing in the run maps: it is reported once, unsplit.
+	exp, err = c.ExpandQuery(ctx, "sleep quality", ExpandOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(exp.Mappings) != 1 || exp.Mappings[0].Term != "sleep quality" || exp.Query != "sleep quality" {
+		t.Errorf("expected unsplit unmapped run, got %+v", exp)
+	}
+}
+
+func TestExpandQuery_SkipsLeadingSCR(t *testing.T) {
+	summary := loadTestdata(t, "mesh_esummary_candidates.json")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/esummary.fcgi" {
+			w.Write(summary)
+			return
+		}
+		// NCBI relevance order puts the Supplementary Concept Record first.
+		w.Write([]byte(`{"esearchresult":{"count":"4","idlist":["67536400","81000235","68018757","68005600"]}}`))
+	}))
+	defer srv.Close()
+
+	exp, err := newTestClient(t, srv.URL).ExpandQuery(context.Background(), "fragile x syndrome", ExpandOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m := exp.Mappings[0]; !m.Mapped() || m.UI != "D005600" {
+		t.Errorf("expected the descriptor behind the SCR to be mapped, got %+v", m)
+	}
+}
@@ -4,11 +4,16 @@ package mesh
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/henrybloomingdale/pubmed-cli/internal/ncbi"
 )
 
+// ErrNotFound is returned, wrapped, when no descriptor matches a term.
+var ErrNotFound = errors.New("not found")
+
 // MeSHRecord represents a MeSH descriptor record.
 type MeSHRecord struct {
 	UI          string   `json:"ui"`
@@ -17,17 +22,38 @@ type MeSHRecord struct {
 	TreeNumbers []string `json:"tree_numbers"`
 	EntryTerms  []string `json:"entry_terms"`
 	Annotation  string   `json:"annotation,omitempty"`
+
+	AllowableQualifiers []Qualifier `json:"allowable_qualifiers,omitempty"`
 }
 
 // Client provides MeSH lookup functionality.
 // It embeds ncbi.BaseClient for shared rate limiting and common parameters.
+// With a local Store, lookups are answered offline.
 type Client struct {
 	*ncbi.BaseClient
+	store *Store
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithStore serves lookups from a local descriptor store instead of NCBI.
+func WithStore(s *Store) Option {
+	return func(c *Client) { c.store = s }
 }
 
 // NewClient creates a new MeSH lookup client using an existing NCBI base client.
-func NewClient(base *ncbi.BaseClient) *Client {
-	return &Client{BaseClient: base}
+func NewClient(base *ncbi.BaseClient, opts ...Option) *Client {
+	c := &Client{BaseClient: base}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Offline reports whether lookups are served from a local store.
+func (c *Client) Offline() bool {
+	return c.store != nil
 }
 
 // esearchResult for parsing MeSH search.
@@ -40,11 +66,15 @@ type meshSearchResult struct {
 	IDList []string `json:"idlist"`
 }
 
-// Lookup searches for a MeSH term and returns its record.
+// Lookup searches for a MeSH term and returns its record. Offline clients
+// also accept descriptor UIs and tree numbers.
 func (c *Client) Lookup(ctx context.Context, term string) (*MeSHRecord, error) {
 	if term == "" {
 		return nil, fmt.Errorf("MeSH term cannot be empty")
 	}
+	if c.store != nil {
+		return c.store.Lookup(term)
+	}
 
 	// Step 1: Search for the term in MeSH database
 	ids, err := c.searchMeSH(ctx, term)
@@ -52,7 +82,7 @@ func (c *Client) Lookup(ctx context.Context, term string) (*MeSHRecord, error) {
 		return nil, err
 	}
 	if len(ids) == 0 {
-		return nil, fmt.Errorf("MeSH term %q not found", term)
+		return nil, fmt.Errorf("MeSH term %q %w", term, ErrNotFound)
 	}
 
 	// Step 2: Fetch the full record
@@ -101,46 +131,80 @@ type esummaryResponse struct {
 
 // esummaryRecord holds the fields we need from a single MeSH esummary record.
 type esummaryRecord struct {
-	UID       string   `json:"uid"`
-	ScopeNote string   `json:"ds_scopenote"`
-	MeshTerms []string `json:"ds_meshterms"`
-	MeshUI    string   `json:"ds_meshui"`
-	IdxLinks  []struct {
-		TreeNum string `json:"treenum"`
-	} `json:"ds_idxlinks"`
+	UID       string        `json:"uid"`
+	ScopeNote string        `json:"ds_scopenote"`
+	MeshTerms []string      `json:"ds_meshterms"`
+	MeshUI    string        `json:"ds_meshui"`
+	IdxLinks  []esummaryIdx `json:"ds_idxlinks"`
+
+	// Subheadings lists allowable qualifiers. It is kept raw so an
+	// unexpected shape cannot break the rest of the record.
+	Subheadings json.RawMessage `json:"ds_subheading"`
 }
 
-func (c *Client) fetchMeSH(ctx context.Context, uid string) (*MeSHRecord, error) {
-	params := make(map[string][]string)
-	vals := map[string]string{
-		"db":      "mesh",
-		"id":      uid,
-		"retmode": "json",
-	}
-	for k, v := range vals {
-		params[k] = []string{v}
-	}
+// esummaryIdx is one tree position of a descriptor, with the ESummary UIDs
+// of the descriptors directly above and below it.
+type esummaryIdx struct {
+	TreeNum  string        `json:"treenum"`
+	Parent   json.Number   `json:"parent"`
+	Children []json.Number `json:"children"`
+}
 
-	body, err := c.DoGet(ctx, "esummary.fcgi", params)
-	if err != nil {
-		return nil, fmt.Errorf("MeSH fetch failed: %w", err)
-	}
+// maxSummaryIDs caps the UIDs sent in one ESummary request.
+const maxSummaryIDs = 200
 
-	var resp esummaryResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing MeSH summary: %w", err)
+func (c *Client) fetchMeSH(ctx context.Context, uid string) (*MeSHRecord, error) {
+	recs, err := c.summaries(ctx, []string{uid})
+	if err != nil {
+		return nil, err
 	}
-
-	raw, ok := resp.Result[uid]
+	rec, ok := recs[uid]
 	if !ok {
 		return nil, fmt.Errorf("MeSH UID %s not found in response", uid)
 	}
+	return rec.record(), nil
+}
+
+// summaries fetches the ESummary records for uids, keyed by UID. UIDs
+// missing from the response are absent from the map.
+func (c *Client) summaries(ctx context.Context, uids []string) (map[string]esummaryRecord, error) {
+	out := make(map[string]esummaryRecord, len(uids))
+	for start := 0; start < len(uids); start += maxSummaryIDs {
+		chunk := uids[start:min(start+maxSummaryIDs, len(uids))]
+		params := map[string][]string{
+			"db":      {"mesh"},
+			"id":      {strings.Join(chunk, ",")},
+			"retmode": {"json"},
+		}
+
+		body, err := c.DoGet(ctx, "esummary.fcgi", params)
+		if err != nil {
+			return nil, fmt.Errorf("MeSH fetch failed: %w", err)
+		}
+
+		var resp esummaryResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("parsing MeSH summary: %w", err)
+		}
 
-	var rec esummaryRecord
-	if err := json.Unmarshal(raw, &rec); err != nil {
-		return nil, fmt.Errorf("parsing MeSH record %s: %w", uid, err)
+		for _, uid := range chunk {
+			raw, ok := resp.Result[uid]
+			if !ok {
+				continue
+			}
+			var rec esummaryRecord
+			if err := json.Unmarshal(raw, &rec); err != nil {
+				return nil, fmt.Errorf("parsing MeSH record %s: %w", uid, err)
+			}
+			rec.UID = uid
+			out[uid] = rec
+		}
 	}
+	return out, nil
+}
 
+// record converts an ESummary record to a MeSHRecord.
+func (rec esummaryRecord) record() *MeSHRecord {
 	record := &MeSHRecord{
 		UI:        rec.MeshUI,
 		ScopeNote: rec.ScopeNote,
@@ -160,5 +224,14 @@ func (c *Client) fetchMeSH(ctx context.Context, uid string) (*MeSHRecord, error)
 		}
 	}
 
-	return record, nil
+	var subheadings []string
+	if json.Unmarshal(rec.Subheadings, &subheadings) == nil {
+		for _, sh := range subheadings {
+			if sh = strings.TrimSpace(sh); sh != "" {
+				record.AllowableQualifiers = append(record.AllowableQualifiers, qualifierFromString(sh))
+			}
+		}
+	}
+
+	return record
 }
@@ -101,6 +101,9 @@ func TestLookup_Success(t *testing.T) {
 	if !found {
 		t.Errorf("expected entry term 'FXS' in entry terms, got: %v", record.EntryTerms)
 	}
+	if len(record.AllowableQualifiers) != 6 || record.AllowableQualifiers[4] != (Qualifier{Name: "genetics", Abbreviation: "GE"}) {
+		t.Errorf("unexpected allowable qualifiers %+v", record.AllowableQualifiers)
+	}
 }
 
 func TestLookup_NotFound(t *testing.T) {
@@ -0,0 +1,228 @@
+package mesh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Qualifier is a MeSH subheading such as genetics (Q000235, GE). Records
+// list the qualifiers allowed with them; fields a source does not provide
+// are empty (the ASCII dump gives only abbreviations, ESummary only names).
+type Qualifier struct {
+	UI           string `json:"ui,omitempty"`
+	Name         string `json:"name"`
+	Abbreviation string `json:"abbreviation,omitempty"`
+}
+
+// String returns the qualifier name, or its abbreviation when the name is
+// unknown.
+func (q Qualifier) String() string {
+	if q.Name != "" {
+		return q.Name
+	}
+	return q.Abbreviation
+}
+
+// qualifierNames maps the two-letter abbreviations of current MeSH
+// qualifiers to their names.
+var qualifierNames = map[string]string{
+	"AA": "analogs & derivatives",
+	"AB": "abnormalities",
+	"AD": "administration & dosage",
+	"AE": "adverse effects",
+	"AG": "agonists",
+	"AH": "anatomy & histology",
+	"AI": "antagonists & inhibitors",
+	"AN": "analysis",
+	"BI": "biosynthesis",
+	"BL": "blood",
+	"BS": "blood supply",
+	"CF": "cerebrospinal fluid",
+	"CH": "chemistry",
+	"CI": "chemically induced",
+	"CL": "classification",
+	"CN": "congenital",
+	"CO": "complications",
+	"CS": "chemical synthesis",
+	"CY": "cytology",
+	"DE": "drug effects",
+	"DF": "deficiency",
+	"DG": "diagnostic imaging",
+	"DH": "diet therapy",
+	"DI": "diagnosis",
+	"DT": "drug therapy",
+	"EC": "economics",
+	"ED": "education",
+	"EH": "ethnology",
+	"EM": "embryology",
+	"EN": "enzymology",
+	"EP": "epidemiology",
+	"ES": "ethics",
+	"ET": "etiology",
+	"GD": "growth & development",
+	"GE": "genetics",
+	"HI": "history",
+	"IM": "immunology",
+	"IN": "injuries",
+	"IP": "isolation & purification",
+	"IR": "innervation",
+	"IS": "instrumentation",
+	"LJ": "legislation & jurisprudence",
+	"MA": "manpower",
+	"ME": "metabolism",
+	"MI": "microbiology",
+	"MO": "mortality",
+	"MT": "methods",
+	"NU": "nursing",
+	"OG": "organization & administration",
+	"PA": "pathology",
+	"PC": "prevention & control",
+	"PD": "pharmacology",
+	"PH": "physiology",
+	"PK": "pharmacokinetics",
+	"PO": "poisoning",
+	"PP": "physiopathology",
+	"PS": "parasitology",
+	"PX": "psychology",
+	"PY": "pathogenicity",
+	"RE": "radiation effects",
+	"RH": "rehabilitation",
+	"RT": "radiotherapy",
+	"SC": "secondary",
+	"SD": "supply & distribution",
+	"SE": "secretion",
+	"SN": "statistics & numerical data",
+	"ST": "standards",
+	"SU": "surgery",
+	"TD": "trends",
+	"TH": "therapy",
+	"TM": "transmission",
+	"TO": "toxicity",
+	"TR": "transplantation",
+	"TU": "therapeutic use",
+	"UL": "ultrastructure",
+	"UR": "urine",
+	"UT": "utilization",
+	"VE": "veterinary",
+	"VI": "virology",
+}
+
+// LookupQualifier finds a qualifier by name or two-letter abbreviation,
+// ignoring case.
+func LookupQualifier(s string) (Qualifier, bool) {
+	s = strings.TrimSpace(s)
+	if name, ok := qualifierNames[strings.ToUpper(s)]; ok {
+		return Qualifier{Name: name, Abbreviation: strings.ToUpper(s)}, true
+	}
+	for abbr, name := range qualifierNames {
+		if strings.EqualFold(name, s) {
+			return Qualifier{Name: name, Abbreviation: abbr}, true
+		}
+	}
+	return Qualifier{}, false
+}
+
+// qualifierFromString builds a Qualifier from a name or abbreviation,
+// filling in the other from the table when it is known.
+func qualifierFromString(s string) Qualifier {
+	if q, ok := LookupQualifier(s); ok {
+		return q
+	}
+	if len(s) == 2 && strings.ToUpper(s) == s {
+		return Qualifier{Abbreviation: s}
+	}
+	return Qualifier{Name: s}
+}
+
+// allows reports whether qualifier q (a name or abbreviation) may be used
+// with the record, returning its canonical form. Records without allowable
+// qualifiers accept any known qualifier.
+func (r *MeSHRecord) allows(q string) (Qualifier, bool) {
+	if len(r.AllowableQualifiers) == 0 {
+		return LookupQualifier(q)
+	}
+	for _, aq := range r.AllowableQualifiers {
+		if strings.EqualFold(aq.Name, q) || strings.EqualFold(aq.Abbreviation, q) {
+			return aq, aq.Name != ""
+		}
+	}
+	return Qualifier{}, false
+}
+
+// Heading is a descriptor with optional qualifiers, written as in PubMed:
+// Fragile X Syndrome/genetics.
+type Heading struct {
+	Descriptor string   `json:"descriptor"`
+	Qualifiers []string `json:"qualifiers,omitempty"`
+}
+
+// ParseHeading parses "Descriptor[/qualifier...]". Qualifiers may be names
+// or two-letter abbreviations (Fragile X Syndrome/GE) and are returned as
+// names when known.
+func ParseHeading(s string) (Heading, error) {
+	parts := strings.Split(s, "/")
+	h := Heading{Descriptor: strings.TrimSpace(parts[0])}
+	if h.Descriptor == "" {
+		return Heading{}, fmt.Errorf("MeSH heading %q has no descriptor", s)
+	}
+	for _, p := range parts[1:] {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			return Heading{}, fmt.Errorf("MeSH heading %q has an empty qualifier", s)
+		}
+		h.Qualifiers = append(h.Qualifiers, qualifierFromString(p).String())
+	}
+	return h, nil
+}
+
+// Query returns the PubMed clause for the heading: "Descriptor"[mh] or
+// "Descriptor/qualifier"[mh], with several qualifiers ORed together.
+// explode=false emits [mh:noexp].
+func (h Heading) Query(explode bool) string {
+	tag := "[mh]"
+	if !explode {
+		tag = "[mh:noexp]"
+	}
+	if len(h.Qualifiers) == 0 {
+		return quoteTerm(h.Descriptor) + tag
+	}
+	clauses := make([]string, len(h.Qualifiers))
+	for i, q := range h.Qualifiers {
+		clauses[i] = quoteTerm(h.Descriptor+"/"+q) + tag
+	}
+	if len(clauses) == 1 {
+		return clauses[0]
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")"
+}
+
+// ResolveHeading parses s and checks it against MeSH: the descriptor is
+// the best-ranked search candidate whose name or an entry term matches,
+// replaced by its preferred name, and each qualifier must be one it
+// allows. Candidates carry the descriptor's full ESummary (or store)
+// record, allowable qualifiers included, so no second fetch is needed.
+func (c *Client) ResolveHeading(ctx context.Context, s string) (Heading, error) {
+	h, err := ParseHeading(s)
+	if err != nil {
+		return Heading{}, err
+	}
+	cand, err := c.topDescriptor(ctx, h.Descriptor)
+	if err != nil {
+		return Heading{}, err
+	}
+	record := &cand.MeSHRecord
+	if !cand.Exact() {
+		return Heading{}, fmt.Errorf("%q is not a MeSH descriptor; closest is %q", h.Descriptor, record.Name)
+	}
+
+	resolved := Heading{Descriptor: record.Name}
+	for _, q := range h.Qualifiers {
+		aq, ok := record.allows(q)
+		if !ok {
+			return Heading{}, fmt.Errorf("qualifier %q is not allowed with %q", q, record.Name)
+		}
+		resolved.Qualifiers = append(resolved.Qualifiers, aq.Name)
+	}
+	return resolved, nil
+}
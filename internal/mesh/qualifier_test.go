@@ -0,0 +1,101 @@
+package mesh
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLookupQualifier(t *testing.T) {
+	for _, s := range []string{"GE", "ge", "Genetics", " genetics "} {
+		if q, ok := LookupQualifier(s); !ok || q != (Qualifier{Name: "genetics", Abbreviation: "GE"}) {
+			t.Errorf("LookupQualifier(%q) = %+v, %v", s, q, ok)
+		}
+	}
+	if _, ok := LookupQualifier("genes"); ok {
+		t.Error("expected unknown qualifier to miss")
+	}
+}
+
+func TestParseHeading(t *testing.T) {
+	h, err := ParseHeading("Fragile X Syndrome / GE/drug therapy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.Descriptor != "Fragile X Syndrome" || strings.Join(h.Qualifiers, "|") != "genetics|drug therapy" {
+		t.Errorf("unexpected heading %+v", h)
+	}
+
+	for _, s := range []string{"", "/genetics", "Autism//genetics", "Autism/"} {
+		if _, err := ParseHeading(s); err == nil {
+			t.Errorf("ParseHeading(%q): expected error", s)
+		}
+	}
+}
+
+func TestHeadingQuery(t *testing.T) {
+	tests := []struct {
+		h       Heading
+		explode bool
+		want    string
+	}{
+		{Heading{Descriptor: "Autistic Disorder"}, true, `"Autistic Disorder"[mh]`},
+		{Heading{Descriptor: "Fragile X Syndrome", Qualifiers: []string{"genetics"}}, true, `"Fragile X Syndrome/genetics"[mh]`},
+		{Heading{Descriptor: "Fragile X Syndrome", Qualifiers: []string{"genetics"}}, false, `"Fragile X Syndrome/genetics"[mh:noexp]`},
+		{Heading{Descriptor: "Fragile X Syndrome", Qualifiers: []string{"genetics", "therapy"}}, true,
+			`("Fragile X Syndrome/genetics"[mh] OR "Fragile X Syndrome/therapy"[mh])`},
+	}
+	for _, tt := range tests {
+		if got := tt.h.Query(tt.explode); got != tt.want {
+			t.Errorf("%+v.Query(%v) = %s, want %s", tt.h, tt.explode, got, tt.want)
+		}
+	}
+}
+
+func TestResolveHeading(t *testing.T) {
+	c := NewClient(nil, WithStore(loadTestStore(t)))
+	ctx := context.Background()
+
+	h, err := c.ResolveHeading(ctx, "fxs/GE")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.Descriptor != "Fragile X Syndrome" || strings.Join(h.Qualifiers, "|") != "genetics" {
+		t.Errorf("unexpected heading %+v", h)
+	}
+
+	if _, err := c.ResolveHeading(ctx, "Fragile X Syndrome/drug therapy"); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("expected disallowed qualifier error, got %v", err)
+	}
+	if _, err := c.ResolveHeading(ctx, "no such heading/genetics"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestResolveHeading_SkipsLeadingSCR(t *testing.T) {
+	summary := loadTestdata(t, "mesh_esummary_candidates.json")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/esummary.fcgi" {
+			w.Write(summary)
+			return
+		}
+		// NCBI relevance order puts the Supplementary Concept Record first.
+		w.Write([]byte(`{"esearchresult":{"count":"4","idlist":["67536400","81000235","68018757","68005600"]}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL)
+	h, err := c.ResolveHeading(context.Background(), "Fragile X Syndrome/GE")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.Descriptor != "Fragile X Syndrome" || strings.Join(h.Qualifiers, "|") != "genetics" {
+		t.Errorf("unexpected heading %+v", h)
+	}
+	if _, err := c.ResolveHeading(context.Background(), "Fragile X Syndrome/drug therapy"); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("expected the descriptor's allowable qualifiers to apply, got %v", err)
+	}
+}
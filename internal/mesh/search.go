@@ -0,0 +1,205 @@
+package mesh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultSearchLimit is the number of candidates Search returns when no
+// limit is given.
+const DefaultSearchLimit = 20
+
+// RecordType is the kind of record in the MeSH database.
+type RecordType string
+
+const (
+	TypeDescriptor            RecordType = "descriptor"
+	TypeQualifier             RecordType = "qualifier"
+	TypeSCR                   RecordType = "scr" // Supplementary Concept Record
+	TypePharmacologicalAction RecordType = "pharmacological_action"
+)
+
+// Match says how a candidate matched the search term.
+type Match string
+
+const (
+	MatchName      Match = "name"       // the record's preferred name
+	MatchEntryTerm Match = "entry_term" // one of its entry terms
+	MatchPartial   Match = "partial"    // contained in the name or an entry term
+	MatchRelated   Match = "related"    // found by NCBI, e.g. in the scope note
+)
+
+// Candidate is one MeSH record matching a search.
+type Candidate struct {
+	MeSHRecord
+	Type  RecordType `json:"type"`
+	Match Match      `json:"match"`
+
+	summary *esummaryRecord // ESummary record behind online candidates
+}
+
+// Search returns up to limit MeSH records matching term, best first: exact
+// name matches, then entry terms, then partial and related matches, with
+// descriptors ahead of qualifiers and Supplementary Concept Records at each
+// level. Offline clients search the store's descriptors.
+func (c *Client) Search(ctx context.Context, term string, limit int) ([]Candidate, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, fmt.Errorf("MeSH term cannot be empty")
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	var candidates []Candidate
+	if c.store != nil {
+		candidates = c.store.Search(term, limit)
+	} else {
+		var err error
+		if candidates, err = c.searchCandidates(ctx, term, limit); err != nil {
+			return nil, err
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("MeSH term %q %w", term, ErrNotFound)
+	}
+	return candidates, nil
+}
+
+func (c *Client) searchCandidates(ctx context.Context, term string, limit int) ([]Candidate, error) {
+	params := map[string][]string{
+		"db":      {"mesh"},
+		"term":    {term},
+		"retmax":  {strconv.Itoa(limit)},
+		"retmode": {"json"},
+	}
+	resp, err := c.DoGet(ctx, "esearch.fcgi", params)
+	if err != nil {
+		return nil, fmt.Errorf("MeSH search failed: %w", err)
+	}
+	var result meshSearchResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("parsing MeSH search response: %w", err)
+	}
+	ids := result.Result.IDList
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	recs, err := c.summaries(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []Candidate
+	for _, uid := range ids {
+		if rec, ok := recs[uid]; ok {
+			cand := newCandidate(*rec.record(), term)
+			cand.summary = &rec
+			candidates = append(candidates, cand)
+		}
+	}
+	rankCandidates(candidates)
+	return candidates, nil
+}
+
+// Search returns up to limit descriptors whose UI, tree number, name, or
+// entry terms match term, ranked as Client.Search ranks them.
+func (s *Store) Search(term string, limit int) []Candidate {
+	upper := strings.ToUpper(strings.TrimSpace(term))
+	if descriptorUIRe.MatchString(upper) || treeNumberRe.MatchString(upper) {
+		if r, err := s.Lookup(upper); err == nil {
+			c := newCandidate(*r, term)
+			c.Match = MatchName
+			return []Candidate{c}
+		}
+	}
+
+	var candidates []Candidate
+	for _, r := range s.records {
+		if c := newCandidate(r, term); c.Match != MatchRelated {
+			candidates = append(candidates, c)
+		}
+	}
+	rankCandidates(candidates)
+	return candidates[:min(limit, len(candidates))]
+}
+
+// topDescriptor returns the best-ranked descriptor among the candidates for
+// term. NCBI often ranks a Supplementary Concept Record or another sense
+// first, so the first ESearch hit is not used. Check Exact to see whether
+// term is the descriptor's name or an entry term.
+func (c *Client) topDescriptor(ctx context.Context, term string) (*Candidate, error) {
+	candidates, err := c.Search(ctx, term, DefaultSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+	for i, cand := range candidates {
+		if cand.Type == TypeDescriptor || cand.Type == TypePharmacologicalAction {
+			return &candidates[i], nil
+		}
+	}
+	return nil, fmt.Errorf("MeSH term %q %w", term, ErrNotFound)
+}
+
+// Exact reports whether the search term is the record's name or one of
+// its entry terms.
+func (c Candidate) Exact() bool {
+	return c.Match == MatchName || c.Match == MatchEntryTerm
+}
+
+func newCandidate(r MeSHRecord, term string) Candidate {
+	return Candidate{MeSHRecord: r, Type: recordType(r), Match: matchTerm(r, term)}
+}
+
+// recordType classifies a record by its UI prefix. Pharmacological actions
+// are descriptors filed under D27.505 (Pharmacologic Actions).
+func recordType(r MeSHRecord) RecordType {
+	switch {
+	case strings.HasPrefix(r.UI, "Q"):
+		return TypeQualifier
+	case strings.HasPrefix(r.UI, "C"):
+		return TypeSCR
+	}
+	for _, tn := range r.TreeNumbers {
+		if strings.HasPrefix(tn, "D27.505.") {
+			return TypePharmacologicalAction
+		}
+	}
+	return TypeDescriptor
+}
+
+func matchTerm(r MeSHRecord, term string) Match {
+	if r.matches(term) {
+		if strings.EqualFold(r.Name, strings.TrimSpace(term)) {
+			return MatchName
+		}
+		return MatchEntryTerm
+	}
+	lower := strings.ToLower(strings.TrimSpace(term))
+	for _, s := range append([]string{r.Name}, r.EntryTerms...) {
+		if strings.Contains(strings.ToLower(s), lower) {
+			return MatchPartial
+		}
+	}
+	return MatchRelated
+}
+
+var (
+	matchRank = map[Match]int{MatchName: 0, MatchEntryTerm: 1, MatchPartial: 2, MatchRelated: 3}
+	typeRank  = map[RecordType]int{TypeDescriptor: 0, TypePharmacologicalAction: 1, TypeQualifier: 2, TypeSCR: 3}
+)
+
+// rankCandidates orders candidates by match, then type, keeping the
+// incoming order (NCBI relevance) among equals.
+func rankCandidates(candidates []Candidate) {
+	slices.SortStableFunc(candidates, func(a, b Candidate) int {
+		if d := matchRank[a.Match] - matchRank[b.Match]; d != 0 {
+			return d
+		}
+		return typeRank[a.Type] - typeRank[b.Type]
+	})
+}
@@ -0,0 +1,96 @@
+package mesh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearch_RanksCandidates(t *testing.T) {
+	summary := loadTestdata(t, "mesh_esummary_candidates.json")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/esearch.fcgi":
+			if q.Get("term") != "fragile x syndrome" || q.Get("retmax") != "5" {
+				t.Errorf("unexpected search params: %v", q)
+			}
+			// NCBI relevance order puts the SCR first.
+			fmt.Fprint(w, `{"esearchresult":{"count":"4","idlist":["67536400","81000235","68018757","68005600"]}}`)
+		case "/esummary.fcgi":
+			if q.Get("id") != "67536400,81000235,68018757,68005600" {
+				t.Errorf("expected one summary request for all hits, got %q", q.Get("id"))
+			}
+			w.Write(summary)
+		}
+	}))
+	defer srv.Close()
+
+	candidates, err := newTestClient(t, srv.URL).Search(context.Background(), "fragile x syndrome", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []struct {
+		ui    string
+		typ   RecordType
+		match Match
+	}{
+		{"D005600", TypeDescriptor, MatchName},
+		{"D018757", TypePharmacologicalAction, MatchRelated},
+		{"Q000235", TypeQualifier, MatchRelated},
+		{"C536400", TypeSCR, MatchRelated},
+	}
+	if len(candidates) != len(want) {
+		t.Fatalf("expected %d candidates, got %+v", len(want), candidates)
+	}
+	for i, w := range want {
+		c := candidates[i]
+		if c.UI != w.ui || c.Type != w.typ || c.Match != w.match {
+			t.Errorf("candidate %d = %s %s %s, want %s %s %s", i, c.UI, c.Type, c.Match, w.ui, w.typ, w.match)
+		}
+	}
+	if candidates[0].Name != "Fragile X Syndrome" || len(candidates[0].TreeNumbers) != 2 {
+		t.Errorf("expected full record for top candidate, got %+v", candidates[0].MeSHRecord)
+	}
+}
+
+func TestSearch_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"esearchresult":{"count":"0","idlist":[]}}`)
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(t, srv.URL).Search(context.Background(), "zzzz", 0)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStoreSearch(t *testing.T) {
+	s := loadTestStore(t)
+
+	got := s.Search("autis", 10)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 partial matches, got %+v", got)
+	}
+	for _, c := range got {
+		if c.Match != MatchPartial || c.Type != TypeDescriptor {
+			t.Errorf("unexpected candidate %s %s %s", c.Name, c.Type, c.Match)
+		}
+	}
+
+	got = s.Search("Autism", 10)
+	if len(got) != 2 || got[0].Name != "Autistic Disorder" || got[0].Match != MatchEntryTerm {
+		t.Errorf("expected entry-term match first, got %+v", got)
+	}
+
+	if got := s.Search("C10.597", 10); len(got) != 1 || got[0].UI != "D009461" {
+		t.Errorf("expected tree number lookup, got %+v", got)
+	}
+	if got := s.Search("syndrome", 1); len(got) != 1 {
+		t.Errorf("expected limit to apply, got %d", len(got))
+	}
+}
@@ -0,0 +1,421 @@
+package mesh
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// storeIndexVersion is bumped whenever the index layout or the records it
+// holds change, so stale indexes are rebuilt from the dump.
+const storeIndexVersion = 2
+
+var (
+	descriptorUIRe = regexp.MustCompile(`^D\d{6,9}$`)
+	treeNumberRe   = regexp.MustCompile(`^[A-Z]\d{2}(\.\d{3})*$`)
+)
+
+// Store is an in-memory MeSH descriptor database built from an NLM
+// descriptor dump (desc20XX.xml or the d20XX.bin ASCII file). It answers
+// lookups without network access.
+type Store struct {
+	records []MeSHRecord
+	byUI    map[string]int
+	byName  map[string]int   // lower-cased descriptor name
+	byEntry map[string][]int // lower-cased entry term
+	byTree  map[string]int
+	below   map[string][]string // tree number -> child tree numbers, sorted
+}
+
+// NewStore indexes records by UI, name, entry term, and tree number, and
+// links each tree number to the ones directly below it.
+func NewStore(records []MeSHRecord) *Store {
+	s := &Store{
+		records: records,
+		byUI:    make(map[string]int, len(records)),
+		byName:  make(map[string]int, len(records)),
+		byEntry: make(map[string][]int),
+		byTree:  make(map[string]int, len(records)),
+		below:   make(map[string][]string),
+	}
+	for i, r := range records {
+		s.byUI[r.UI] = i
+		s.byName[strings.ToLower(r.Name)] = i
+		for _, et := range r.EntryTerms {
+			key := strings.ToLower(et)
+			s.byEntry[key] = append(s.byEntry[key], i)
+		}
+		for _, tn := range r.TreeNumbers {
+			s.byTree[tn] = i
+			if parent := parentTreeNumber(tn); parent != "" {
+				s.below[parent] = append(s.below[parent], tn)
+			}
+		}
+	}
+	for _, children := range s.below {
+		slices.Sort(children)
+	}
+	return s
+}
+
+// Len returns the number of descriptors in the store.
+func (s *Store) Len() int {
+	return len(s.records)
+}
+
+// Get returns the descriptor with the given UI (e.g. D005600).
+func (s *Store) Get(ui string) (*MeSHRecord, bool) {
+	return s.record(s.byUI, strings.ToUpper(strings.TrimSpace(ui)))
+}
+
+// ByTreeNumber returns the descriptor at a tree number (e.g. C10.597).
+func (s *Store) ByTreeNumber(tn string) (*MeSHRecord, bool) {
+	return s.record(s.byTree, strings.ToUpper(strings.TrimSpace(tn)))
+}
+
+// ByName returns the descriptor whose preferred name is name, ignoring case.
+func (s *Store) ByName(name string) (*MeSHRecord, bool) {
+	return s.record(s.byName, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// ByEntryTerm returns the descriptors listing term as an entry term,
+// ignoring case. A term can be an entry term of several descriptors.
+func (s *Store) ByEntryTerm(term string) []*MeSHRecord {
+	var out []*MeSHRecord
+	for _, i := range s.byEntry[strings.ToLower(strings.TrimSpace(term))] {
+		r := s.records[i]
+		out = append(out, &r)
+	}
+	return out
+}
+
+// Lookup resolves term as a descriptor UI, a tree number, a descriptor
+// name, or an entry term, in that order.
+func (s *Store) Lookup(term string) (*MeSHRecord, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, fmt.Errorf("MeSH term cannot be empty")
+	}
+	upper := strings.ToUpper(term)
+	if descriptorUIRe.MatchString(upper) {
+		if r, ok := s.Get(upper); ok {
+			return r, nil
+		}
+	}
+	if treeNumberRe.MatchString(upper) {
+		if r, ok := s.ByTreeNumber(upper); ok {
+			return r, nil
+		}
+	}
+	if r, ok := s.ByName(term); ok {
+		return r, nil
+	}
+	if rs := s.ByEntryTerm(term); len(rs) > 0 {
+		return rs[0], nil
+	}
+	return nil, fmt.Errorf("MeSH term %q %w", term, ErrNotFound)
+}
+
+// record returns a copy of the record indexed under key.
+func (s *Store) record(index map[string]int, key string) (*MeSHRecord, bool) {
+	i, ok := index[key]
+	if !ok {
+		return nil, false
+	}
+	r := s.records[i]
+	return &r, true
+}
+
+// OpenStore loads a descriptor dump. The format (XML or ASCII, optionally
+// gzip-compressed) is detected from the content. When indexDir is set, the
+// parsed records are saved there as a compact index and reused on later
+// runs until the dump's size or modification time changes.
+func OpenStore(path, indexDir string) (*Store, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening MeSH file: %w", err)
+	}
+
+	var idxPath string
+	if indexDir != "" {
+		idxPath = indexPath(indexDir, path)
+		if records, err := readIndex(idxPath, info); err == nil {
+			return NewStore(records), nil
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening MeSH file: %w", err)
+	}
+	defer f.Close()
+
+	records, err := ReadDescriptors(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s contains no MeSH descriptors", filepath.Base(path))
+	}
+
+	if idxPath != "" {
+		// The index is only an optimization; a failed write is not fatal.
+		_ = writeIndex(idxPath, info, records)
+	}
+	return NewStore(records), nil
+}
+
+// ReadDescriptors parses an NLM descriptor dump in XML or ASCII (MeSH .bin)
+// format, either of which may be gzip-compressed.
+func ReadDescriptors(r io.Reader) ([]MeSHRecord, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("opening gzip stream: %w", err)
+		}
+		defer gz.Close()
+		br = bufio.NewReader(gz)
+	}
+
+	head, _ := br.Peek(512)
+	head = bytes.TrimLeft(head, " \t\r\n\ufeff")
+	switch {
+	case bytes.HasPrefix(head, []byte("<")):
+		return ParseDescriptorXML(br)
+	case bytes.HasPrefix(head, []byte("*NEWRECORD")):
+		return ParseDescriptorASCII(br)
+	}
+	return nil, fmt.Errorf("unrecognized MeSH file format; expected desc XML or ASCII .bin")
+}
+
+// NLM descriptor XML structures (DescriptorRecordSet DTD).
+type xmlDescriptor struct {
+	UI          string       `xml:"DescriptorUI"`
+	Name        string       `xml:"DescriptorName>String"`
+	Annotation  string       `xml:"Annotation"`
+	TreeNumbers []string     `xml:"TreeNumberList>TreeNumber"`
+	Concepts    []xmlConcept `xml:"ConceptList>Concept"`
+	Qualifiers  []xmlAllowed `xml:"AllowableQualifiersList>AllowableQualifier"`
+}
+
+type xmlAllowed struct {
+	UI           string `xml:"QualifierReferredTo>QualifierUI"`
+	Name         string `xml:"QualifierReferredTo>QualifierName>String"`
+	Abbreviation string `xml:"Abbreviation"`
+}
+
+type xmlConcept struct {
+	Preferred string    `xml:"PreferredConceptYN,attr"`
+	ScopeNote string    `xml:"ScopeNote"`
+	Terms     []xmlTerm `xml:"TermList>Term"`
+}
+
+type xmlTerm struct {
+	Permuted string `xml:"IsPermutedTermYN,attr"`
+	String   string `xml:"String"`
+}
+
+// ParseDescriptorXML streams DescriptorRecord elements from an NLM
+// desc20XX.xml file. Entry terms are the non-permuted terms of every
+// concept other than the descriptor name; the scope note comes from the
+// preferred concept.
+func ParseDescriptorXML(r io.Reader) ([]MeSHRecord, error) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+
+	var records []MeSHRecord
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing descriptor XML: %w", err)
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Local != "DescriptorRecord" {
+			continue
+		}
+		var d xmlDescriptor
+		if err := dec.DecodeElement(&d, &se); err != nil {
+			return nil, fmt.Errorf("parsing descriptor record: %w", err)
+		}
+		records = append(records, convertXMLDescriptor(d))
+	}
+	return records, nil
+}
+
+func convertXMLDescriptor(d xmlDescriptor) MeSHRecord {
+	r := MeSHRecord{
+		UI:          strings.TrimSpace(d.UI),
+		Name:        strings.TrimSpace(d.Name),
+		Annotation:  strings.TrimSpace(d.Annotation),
+		TreeNumbers: d.TreeNumbers,
+	}
+	for _, q := range d.Qualifiers {
+		r.AllowableQualifiers = append(r.AllowableQualifiers, Qualifier{
+			UI:           strings.TrimSpace(q.UI),
+			Name:         strings.TrimSpace(q.Name),
+			Abbreviation: strings.TrimSpace(q.Abbreviation),
+		})
+	}
+	seen := map[string]bool{strings.ToLower(r.Name): true}
+	for _, c := range d.Concepts {
+		if c.Preferred == "Y" {
+			r.ScopeNote = strings.TrimSpace(c.ScopeNote)
+		}
+		for _, t := range c.Terms {
+			term := strings.TrimSpace(t.String)
+			if t.Permuted == "Y" || term == "" || seen[strings.ToLower(term)] {
+				continue
+			}
+			seen[strings.ToLower(term)] = true
+			r.EntryTerms = append(r.EntryTerms, term)
+		}
+	}
+	return r
+}
+
+// ParseDescriptorASCII parses the MeSH ASCII format (d20XX.bin): records
+// start with *NEWRECORD and hold "FIELD = value" lines. MH is the heading,
+// UI the descriptor UI, MN a tree number, MS the scope note, AN the
+// annotation, AQ the allowable qualifier abbreviations, and ENTRY /
+// PRINT ENTRY an entry term followed by |-separated attributes.
+func ParseDescriptorASCII(r io.Reader) ([]MeSHRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		records []MeSHRecord
+		cur     *MeSHRecord
+	)
+	flush := func() {
+		if cur != nil && cur.UI != "" {
+			records = append(records, *cur)
+		}
+		cur = nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "*NEWRECORD" {
+			flush()
+			cur = &MeSHRecord{}
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		key, value, ok := strings.Cut(line, " = ")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "MH":
+			cur.Name = value
+		case "UI":
+			cur.UI = value
+		case "MN":
+			cur.TreeNumbers = append(cur.TreeNumbers, value)
+		case "MS":
+			cur.ScopeNote = value
+		case "AN":
+			cur.Annotation = value
+		case "AQ":
+			for _, abbr := range strings.Fields(value) {
+				cur.AllowableQualifiers = append(cur.AllowableQualifiers, qualifierFromString(abbr))
+			}
+		case "ENTRY", "PRINT ENTRY":
+			term, _, _ := strings.Cut(value, "|")
+			if term = strings.TrimSpace(term); term != "" {
+				cur.EntryTerms = append(cur.EntryTerms, term)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading descriptor ASCII file: %w", err)
+	}
+	flush()
+	return records, nil
+}
+
+// storeIndex is the on-disk form of a parsed dump, tagged with the dump's
+// size and modification time.
+type storeIndex struct {
+	Version int
+	Size    int64
+	ModTime int64
+	Records []MeSHRecord
+}
+
+// indexPath names the index file for a dump by a hash of its absolute path.
+func indexPath(dir, path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Join(dir, "desc-"+hex.EncodeToString(sum[:8])+".gob.gz")
+}
+
+func readIndex(path string, src os.FileInfo) ([]MeSHRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var idx storeIndex
+	if err := gob.NewDecoder(gz).Decode(&idx); err != nil {
+		return nil, err
+	}
+	if idx.Version != storeIndexVersion || idx.Size != src.Size() || idx.ModTime != src.ModTime().UnixNano() {
+		return nil, fmt.Errorf("stale MeSH index")
+	}
+	return idx.Records, nil
+}
+
+// writeIndex saves records atomically via a temporary file and rename.
+func writeIndex(path string, src os.FileInfo, records []MeSHRecord) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".desc-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	gz := gzip.NewWriter(tmp)
+	idx := storeIndex{Version: storeIndexVersion, Size: src.Size(), ModTime: src.ModTime().UnixNano(), Records: records}
+	if err := gob.NewEncoder(gz).Encode(idx); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,199 @@
+package mesh
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/ncbi"
+)
+
+func loadTestStore(t *testing.T) *Store {
+	t.Helper()
+	records, err := ParseDescriptorXML(bytes.NewReader(loadTestdata(t, "mesh_desc_sample.xml")))
+	if err != nil {
+		t.Fatalf("parsing descriptor XML: %v", err)
+	}
+	return NewStore(records)
+}
+
+func TestParseDescriptorXML(t *testing.T) {
+	s := loadTestStore(t)
+	if s.Len() != 16 {
+		t.Fatalf("expected 16 descriptors, got %d", s.Len())
+	}
+
+	r, ok := s.Get("D005600")
+	if !ok {
+		t.Fatal("expected D005600 in store")
+	}
+	if r.Name != "Fragile X Syndrome" {
+		t.Errorf("unexpected name %q", r.Name)
+	}
+	if len(r.TreeNumbers) != 3 || r.TreeNumbers[0] != "C10.597.606.360.320" {
+		t.Errorf("unexpected tree numbers %v", r.TreeNumbers)
+	}
+	if !strings.HasPrefix(r.ScopeNote, "A condition characterized") || strings.HasSuffix(r.ScopeNote, "\n") {
+		t.Errorf("unexpected scope note %q", r.ScopeNote)
+	}
+	want := []string{"Fra(X) Syndrome", "FXS", "Martin-Bell Syndrome", "Fragile X Mental Retardation Syndrome"}
+	if strings.Join(r.EntryTerms, "|") != strings.Join(want, "|") {
+		t.Errorf("expected entry terms %v (no heading or permuted terms), got %v", want, r.EntryTerms)
+	}
+	if !strings.Contains(r.Annotation, "FRAGILE X MENTAL RETARDATION PROTEIN") {
+		t.Errorf("unexpected annotation %q", r.Annotation)
+	}
+	if len(r.AllowableQualifiers) != 3 || r.AllowableQualifiers[0] != (Qualifier{UI: "Q000235", Name: "genetics", Abbreviation: "GE"}) {
+		t.Errorf("unexpected allowable qualifiers %+v", r.AllowableQualifiers)
+	}
+}
+
+func TestParseDescriptorASCII_MatchesXML(t *testing.T) {
+	records, err := ParseDescriptorASCII(bytes.NewReader(loadTestdata(t, "mesh_desc_sample.bin")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+
+	xmlStore := loadTestStore(t)
+	for _, got := range records {
+		want, ok := xmlStore.Get(got.UI)
+		if !ok {
+			t.Fatalf("ASCII record %s missing from XML", got.UI)
+		}
+		if got.Name != want.Name || got.ScopeNote != want.ScopeNote || got.Annotation != want.Annotation ||
+			strings.Join(got.TreeNumbers, "|") != strings.Join(want.TreeNumbers, "|") ||
+			strings.Join(got.EntryTerms, "|") != strings.Join(want.EntryTerms, "|") {
+			t.Errorf("ASCII record differs from XML:\n got  %+v\n want %+v", got, *want)
+		}
+		// The ASCII AQ field has abbreviations only; names come from the table.
+		if len(got.AllowableQualifiers) != len(want.AllowableQualifiers) {
+			t.Errorf("%s: %d qualifiers, want %d", got.UI, len(got.AllowableQualifiers), len(want.AllowableQualifiers))
+			continue
+		}
+		for i, q := range got.AllowableQualifiers {
+			if w := want.AllowableQualifiers[i]; q.Name != w.Name || q.Abbreviation != w.Abbreviation {
+				t.Errorf("%s qualifier %d = %+v, want %+v", got.UI, i, q, w)
+			}
+		}
+	}
+}
+
+func TestReadDescriptors_DetectsFormat(t *testing.T) {
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write(loadTestdata(t, "mesh_desc_sample.bin"))
+	w.Close()
+
+	for name, data := range map[string][]byte{
+		"xml":      loadTestdata(t, "mesh_desc_sample.xml"),
+		"ascii.gz": gz.Bytes(),
+	} {
+		records, err := ReadDescriptors(bytes.NewReader(data))
+		if err != nil || len(records) == 0 {
+			t.Errorf("%s: expected records, got %d, %v", name, len(records), err)
+		}
+	}
+
+	if _, err := ReadDescriptors(strings.NewReader("UI,Name\n")); err == nil {
+		t.Error("expected error for unrecognized format")
+	}
+}
+
+func TestStoreLookup(t *testing.T) {
+	s := loadTestStore(t)
+	tests := map[string]string{
+		"D005600":                 "Fragile X Syndrome", // UI
+		"d001321":                 "Autistic Disorder",  // UI, lower case
+		"C10.597.606.360.320":     "Fragile X Syndrome", // tree number
+		"f03.625.164.113":         "Autism Spectrum Disorder",
+		"fragile x syndrome":      "Fragile X Syndrome", // name
+		"Autism":                  "Autistic Disorder",  // entry term
+		"  Martin-Bell Syndrome ": "Fragile X Syndrome",
+	}
+	for term, want := range tests {
+		r, err := s.Lookup(term)
+		if err != nil {
+			t.Errorf("Lookup(%q): unexpected error: %v", term, err)
+			continue
+		}
+		if r.Name != want {
+			t.Errorf("Lookup(%q) = %q, want %q", term, r.Name, want)
+		}
+	}
+
+	if _, err := s.Lookup("Nonexistent Term"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not-found error, got %v", err)
+	}
+	if _, err := s.Lookup(" "); err == nil {
+		t.Error("expected error for empty term")
+	}
+}
+
+func TestOpenStore_WritesAndReusesIndex(t *testing.T) {
+	dir := t.TempDir()
+	dump := filepath.Join(dir, "desc2025.xml")
+	if err := os.WriteFile(dump, loadTestdata(t, "mesh_desc_sample.xml"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	indexDir := filepath.Join(dir, "index")
+
+	s, err := OpenStore(dump, indexDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Len() != 16 {
+		t.Fatalf("expected 16 descriptors, got %d", s.Len())
+	}
+	idx := indexPath(indexDir, dump)
+	if _, err := os.Stat(idx); err != nil {
+		t.Fatalf("expected index file: %v", err)
+	}
+
+	// Corrupt the dump without changing size or mtime: the index is used.
+	info, _ := os.Stat(dump)
+	garbage := bytes.Repeat([]byte("x"), int(info.Size()))
+	if err := os.WriteFile(dump, garbage, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(dump, info.ModTime(), info.ModTime())
+	if s, err = OpenStore(dump, indexDir); err != nil || s.Len() != 16 {
+		t.Fatalf("expected store from index, got %v", err)
+	}
+
+	// A changed dump invalidates the index.
+	os.Chtimes(dump, info.ModTime().Add(time.Hour), info.ModTime().Add(time.Hour))
+	if _, err := OpenStore(dump, indexDir); err == nil {
+		t.Error("expected stale index to be ignored and the garbage dump rejected")
+	}
+}
+
+func TestOpenStore_MissingFile(t *testing.T) {
+	if _, err := OpenStore(filepath.Join(t.TempDir(), "missing.xml"), ""); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestClientLookup_Offline(t *testing.T) {
+	// No server: an offline client must not touch the network.
+	base := ncbi.NewBaseClient(ncbi.WithBaseURL("http://127.0.0.1:1"))
+	c := NewClient(base, WithStore(loadTestStore(t)))
+	if !c.Offline() {
+		t.Fatal("expected offline client")
+	}
+
+	r, err := c.Lookup(context.Background(), "FXS")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.UI != "D005600" {
+		t.Errorf("expected D005600, got %s", r.UI)
+	}
+}
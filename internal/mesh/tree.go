@@ -0,0 +1,377 @@
+package mesh
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TreeNode is a descriptor at one position in the MeSH hierarchy. A
+// descriptor with several tree numbers appears once per position.
+type TreeNode struct {
+	TreeNumber string `json:"tree_number"`
+	UI         string `json:"ui"`
+	Name       string `json:"name"`
+
+	uid string // ESummary UID, set by online lookups
+}
+
+// Tree is the hierarchy below a descriptor as an adjacency list keyed by
+// tree number. Roots are the descriptor's own tree numbers.
+type Tree struct {
+	UI       string              `json:"ui"`
+	Name     string              `json:"name"`
+	Depth    int                 `json:"depth"`
+	Roots    []string            `json:"roots"`
+	Nodes    map[string]TreeNode `json:"nodes"`
+	Children map[string][]string `json:"children"`
+}
+
+// parentTreeNumber returns the tree number one level above tn, or "" for a
+// top-level heading such as C10.
+func parentTreeNumber(tn string) string {
+	i := strings.LastIndexByte(tn, '.')
+	if i < 0 {
+		return ""
+	}
+	return tn[:i]
+}
+
+// hierarchy walks the MeSH tree, from a local store or from ESummary.
+type hierarchy interface {
+	// locate returns the positions of the descriptor matching term.
+	locate(ctx context.Context, term string) ([]TreeNode, error)
+	// below returns the children of each node, keyed by its tree number.
+	below(ctx context.Context, nodes []TreeNode) (map[string][]TreeNode, error)
+	// above returns the parent of each node that is not a top-level heading.
+	above(ctx context.Context, nodes []TreeNode) ([]TreeNode, error)
+}
+
+func (c *Client) hierarchy() hierarchy {
+	if c.store != nil {
+		return storeHierarchy{c.store}
+	}
+	return &onlineHierarchy{c: c, cache: make(map[string]esummaryRecord)}
+}
+
+// Location is a descriptor's positions in the MeSH tree. Walks from a
+// Location share its lookups, so a term is located once however many of
+// them run.
+type Location struct {
+	Nodes []TreeNode
+
+	h hierarchy
+}
+
+// Locate finds the tree positions of the descriptor matching term: the
+// top-ranked descriptor candidate online, or the store's record offline.
+func (c *Client) Locate(ctx context.Context, term string) (*Location, error) {
+	h := c.hierarchy()
+	nodes, err := h.locate(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return &Location{Nodes: nodes, h: h}, nil
+}
+
+// Children returns the descriptors directly below term at each of its tree
+// numbers, ordered by tree number.
+func (c *Client) Children(ctx context.Context, term string) ([]TreeNode, error) {
+	loc, err := c.Locate(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return loc.Children(ctx)
+}
+
+// Parents returns the descriptors directly above term, one per tree number.
+// Top-level headings have no parents.
+func (c *Client) Parents(ctx context.Context, term string) ([]TreeNode, error) {
+	loc, err := c.Locate(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return loc.Parents(ctx)
+}
+
+// Siblings returns the other descriptors sharing a parent with term,
+// ordered by tree number.
+func (c *Client) Siblings(ctx context.Context, term string) ([]TreeNode, error) {
+	loc, err := c.Locate(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return loc.Siblings(ctx)
+}
+
+// Descendants returns the hierarchy below term down to depth levels, or
+// all levels when depth < 1.
+func (c *Client) Descendants(ctx context.Context, term string, depth int) (*Tree, error) {
+	loc, err := c.Locate(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return loc.Descendants(ctx, depth)
+}
+
+// Children returns the descriptors directly below each position.
+func (l *Location) Children(ctx context.Context) ([]TreeNode, error) {
+	below, err := l.h.below(ctx, l.Nodes)
+	if err != nil {
+		return nil, err
+	}
+	var out []TreeNode
+	for _, n := range l.Nodes {
+		out = append(out, below[n.TreeNumber]...)
+	}
+	return out, nil
+}
+
+// Parents returns the descriptor above each position.
+func (l *Location) Parents(ctx context.Context) ([]TreeNode, error) {
+	return l.h.above(ctx, l.Nodes)
+}
+
+// Siblings returns the other descriptors sharing a parent with it.
+func (l *Location) Siblings(ctx context.Context) ([]TreeNode, error) {
+	parents, err := l.h.above(ctx, l.Nodes)
+	if err != nil {
+		return nil, err
+	}
+	below, err := l.h.below(ctx, parents)
+	if err != nil {
+		return nil, err
+	}
+	self := l.Nodes[0].UI
+	var out []TreeNode
+	for _, p := range parents {
+		for _, n := range below[p.TreeNumber] {
+			if n.UI != self {
+				out = append(out, n)
+			}
+		}
+	}
+	slices.SortFunc(out, func(a, b TreeNode) int { return strings.Compare(a.TreeNumber, b.TreeNumber) })
+	return slices.CompactFunc(out, func(a, b TreeNode) bool { return a.TreeNumber == b.TreeNumber }), nil
+}
+
+// Descendants returns the hierarchy below the positions down to depth
+// levels, or all levels when depth < 1.
+func (l *Location) Descendants(ctx context.Context, depth int) (*Tree, error) {
+	h, nodes := l.h, l.Nodes
+	tree := &Tree{
+		UI:       nodes[0].UI,
+		Name:     nodes[0].Name,
+		Depth:    depth,
+		Nodes:    make(map[string]TreeNode),
+		Children: make(map[string][]string),
+	}
+	for _, n := range nodes {
+		tree.Roots = append(tree.Roots, n.TreeNumber)
+		tree.Nodes[n.TreeNumber] = n
+	}
+
+	level := nodes
+	for d := 0; (depth < 1 || d < depth) && len(level) > 0; d++ {
+		below, err := h.below(ctx, level)
+		if err != nil {
+			return nil, err
+		}
+		var next []TreeNode
+		for _, n := range level {
+			for _, child := range below[n.TreeNumber] {
+				tree.Nodes[child.TreeNumber] = child
+				tree.Children[n.TreeNumber] = append(tree.Children[n.TreeNumber], child.TreeNumber)
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+	return tree, nil
+}
+
+// storeHierarchy walks a local descriptor store.
+type storeHierarchy struct {
+	s *Store
+}
+
+func (h storeHierarchy) locate(_ context.Context, term string) ([]TreeNode, error) {
+	r, err := h.s.Lookup(term)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.TreeNumbers) == 0 {
+		return nil, fmt.Errorf("MeSH term %q has no tree numbers", r.Name)
+	}
+	// A tree number names one position, not every position of its descriptor.
+	if tn := strings.ToUpper(strings.TrimSpace(term)); slices.Contains(r.TreeNumbers, tn) {
+		return []TreeNode{{TreeNumber: tn, UI: r.UI, Name: r.Name}}, nil
+	}
+	nodes := make([]TreeNode, len(r.TreeNumbers))
+	for i, tn := range r.TreeNumbers {
+		nodes[i] = TreeNode{TreeNumber: tn, UI: r.UI, Name: r.Name}
+	}
+	return nodes, nil
+}
+
+func (h storeHierarchy) node(tn string) (TreeNode, bool) {
+	r, ok := h.s.ByTreeNumber(tn)
+	if !ok {
+		return TreeNode{}, false
+	}
+	return TreeNode{TreeNumber: tn, UI: r.UI, Name: r.Name}, true
+}
+
+func (h storeHierarchy) below(_ context.Context, nodes []TreeNode) (map[string][]TreeNode, error) {
+	out := make(map[string][]TreeNode, len(nodes))
+	for _, n := range nodes {
+		for _, tn := range h.s.below[n.TreeNumber] {
+			if child, ok := h.node(tn); ok {
+				out[n.TreeNumber] = append(out[n.TreeNumber], child)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (h storeHierarchy) above(_ context.Context, nodes []TreeNode) ([]TreeNode, error) {
+	var out []TreeNode
+	for _, n := range nodes {
+		if parent, ok := h.node(parentTreeNumber(n.TreeNumber)); ok {
+			out = append(out, parent)
+		}
+	}
+	return out, nil
+}
+
+// onlineHierarchy walks the tree through the parent and children UIDs in
+// MeSH ESummary records, fetching each level in one request.
+type onlineHierarchy struct {
+	c     *Client
+	cache map[string]esummaryRecord
+}
+
+func (h *onlineHierarchy) locate(ctx context.Context, term string) ([]TreeNode, error) {
+	// The first ESearch hit is often a Supplementary Concept Record or
+	// another sense; take the top-ranked descriptor instead. Its ESummary
+	// record came with the search, so it seeds the walk's cache.
+	cand, err := h.c.topDescriptor(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	if cand.summary == nil {
+		return nil, fmt.Errorf("MeSH record %s has no summary", cand.UI)
+	}
+	rec := *cand.summary
+	h.cache[rec.UID] = rec
+
+	var nodes []TreeNode
+	for _, link := range rec.IdxLinks {
+		if link.TreeNum != "" {
+			nodes = append(nodes, rec.node(link.TreeNum))
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("MeSH term %q has no tree numbers", rec.record().Name)
+	}
+	return nodes, nil
+}
+
+func (h *onlineHierarchy) below(ctx context.Context, nodes []TreeNode) (map[string][]TreeNode, error) {
+	var uids []string
+	for _, n := range nodes {
+		if link, ok := h.link(n); ok {
+			for _, child := range link.Children {
+				uids = append(uids, child.String())
+			}
+		}
+	}
+	recs, err := h.fetch(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]TreeNode, len(nodes))
+	for _, n := range nodes {
+		link, _ := h.link(n)
+		var children []TreeNode
+		for _, uid := range link.Children {
+			rec, ok := recs[uid.String()]
+			if !ok {
+				continue
+			}
+			// Pick the child's position under this node; it may have others.
+			for _, cl := range rec.IdxLinks {
+				if parentTreeNumber(cl.TreeNum) == n.TreeNumber {
+					children = append(children, rec.node(cl.TreeNum))
+				}
+			}
+		}
+		slices.SortFunc(children, func(a, b TreeNode) int { return strings.Compare(a.TreeNumber, b.TreeNumber) })
+		out[n.TreeNumber] = children
+	}
+	return out, nil
+}
+
+func (h *onlineHierarchy) above(ctx context.Context, nodes []TreeNode) ([]TreeNode, error) {
+	var uids []string
+	for _, n := range nodes {
+		if link, ok := h.link(n); ok && parentTreeNumber(n.TreeNumber) != "" && link.Parent != "" {
+			uids = append(uids, link.Parent.String())
+		}
+	}
+	recs, err := h.fetch(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []TreeNode
+	for _, n := range nodes {
+		link, _ := h.link(n)
+		tn := parentTreeNumber(n.TreeNumber)
+		if rec, ok := recs[link.Parent.String()]; ok && tn != "" {
+			out = append(out, rec.node(tn))
+		}
+	}
+	return out, nil
+}
+
+// link returns the ESummary tree position behind n.
+func (h *onlineHierarchy) link(n TreeNode) (esummaryIdx, bool) {
+	for _, link := range h.cache[n.uid].IdxLinks {
+		if link.TreeNum == n.TreeNumber {
+			return link, true
+		}
+	}
+	return esummaryIdx{}, false
+}
+
+// fetch returns the ESummary records for uids, requesting only those not
+// seen earlier in the walk.
+func (h *onlineHierarchy) fetch(ctx context.Context, uids []string) (map[string]esummaryRecord, error) {
+	var missing []string
+	seen := make(map[string]bool, len(uids))
+	for _, uid := range uids {
+		if _, ok := h.cache[uid]; !ok && !seen[uid] {
+			seen[uid] = true
+			missing = append(missing, uid)
+		}
+	}
+	if len(missing) > 0 {
+		recs, err := h.c.summaries(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for uid, rec := range recs {
+			h.cache[uid] = rec
+		}
+	}
+	return h.cache, nil
+}
+
+// node returns the record's position at tree number tn.
+func (rec esummaryRecord) node(tn string) TreeNode {
+	r := rec.record()
+	return TreeNode{TreeNumber: tn, UI: r.UI, Name: r.Name, uid: rec.UID}
+}
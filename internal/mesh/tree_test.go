@@ -0,0 +1,154 @@
+package mesh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func treeNumbers(nodes []TreeNode) string {
+	tns := make([]string, len(nodes))
+	for i, n := range nodes {
+		tns[i] = n.TreeNumber
+	}
+	return strings.Join(tns, " ")
+}
+
+func TestTree_Store(t *testing.T) {
+	c := NewClient(nil, WithStore(loadTestStore(t)))
+	ctx := context.Background()
+
+	children, err := c.Children(ctx, "Intellectual Disability")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "C10.597.606.360.220 C10.597.606.360.320 F03.625.539.220 F03.625.539.320"
+	if got := treeNumbers(children); got != want {
+		t.Errorf("Children = %s, want %s", got, want)
+	}
+	if children[1].Name != "Fragile X Syndrome" || children[1].UI != "D005600" {
+		t.Errorf("unexpected child %+v", children[1])
+	}
+
+	// A tree number selects one position.
+	children, err = c.Children(ctx, "F03.625.539")
+	if err != nil || treeNumbers(children) != "F03.625.539.220 F03.625.539.320" {
+		t.Errorf("Children(F03.625.539) = %s, %v", treeNumbers(children), err)
+	}
+
+	parents, err := c.Parents(ctx, "FXS")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := treeNumbers(parents); got != "C10.597.606.360 C16.320.322 F03.625.539" {
+		t.Errorf("Parents = %s", got)
+	}
+	if parents, _ := c.Parents(ctx, "C10"); len(parents) != 0 {
+		t.Errorf("expected no parents for a top-level heading, got %v", parents)
+	}
+
+	siblings, err := c.Siblings(ctx, "Fragile X Syndrome")
+	if err != nil || treeNumbers(siblings) != "C10.597.606.360.220 F03.625.539.220" {
+		t.Errorf("Siblings = %s, %v", treeNumbers(siblings), err)
+	}
+}
+
+func TestDescendants_Store(t *testing.T) {
+	c := NewClient(nil, WithStore(loadTestStore(t)))
+
+	tree, err := c.Descendants(context.Background(), "Nervous System Diseases", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tree.UI != "D009422" || strings.Join(tree.Roots, " ") != "C10" {
+		t.Errorf("unexpected root: %s %v", tree.UI, tree.Roots)
+	}
+	if got := strings.Join(tree.Children["C10"], " "); got != "C10.597" {
+		t.Errorf("C10 children = %s", got)
+	}
+	if got := strings.Join(tree.Children["C10.597"], " "); got != "C10.597.606" {
+		t.Errorf("C10.597 children = %s", got)
+	}
+	if _, ok := tree.Children["C10.597.606"]; ok {
+		t.Error("expected the walk to stop at depth 2")
+	}
+	if len(tree.Nodes) != 3 {
+		t.Errorf("expected 3 nodes, got %d", len(tree.Nodes))
+	}
+
+	all, err := c.Descendants(context.Background(), "C10", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, ok := all.Nodes["C10.597.606.360.320"]; !ok || n.Name != "Fragile X Syndrome" {
+		t.Errorf("expected all levels with depth 0, got %v", all.Children)
+	}
+}
+
+func TestTree_Online(t *testing.T) {
+	fixture := loadTestdata(t, "mesh_esummary_tree.json")
+	var searches int
+	var summaryIDs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/esearch.fcgi":
+			searches++
+			// The broader heading comes first in NCBI relevance order.
+			fmt.Fprint(w, `{"esearchresult":{"count":"2","idlist":["68019954","68008607"]}}`)
+		case "/esummary.fcgi":
+			summaryIDs = append(summaryIDs, r.URL.Query().Get("id"))
+			w.Write(fixture)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+	c := newTestClient(t, srv.URL)
+	ctx := context.Background()
+
+	loc, err := c.Locate(ctx, "Mental Retardation")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loc.Nodes) != 2 || loc.Nodes[0].UI != "D008607" {
+		t.Fatalf("expected Intellectual Disability, got %+v", loc.Nodes)
+	}
+
+	tree, err := loc.Descendants(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(tree.Roots, " ") != "C10.597.606.360 F03.625.539" {
+		t.Errorf("unexpected roots %v", tree.Roots)
+	}
+	if got := strings.Join(tree.Children["F03.625.539"], " "); got != "F03.625.539.220 F03.625.539.320" {
+		t.Errorf("F03.625.539 children = %s", got)
+	}
+	if n := tree.Nodes["C10.597.606.360.320"]; n.UI != "D005600" || n.Name != "Fragile X Syndrome" {
+		t.Errorf("unexpected node %+v", n)
+	}
+	// One request for the search candidates, one for all children; the
+	// term's own record comes with the search.
+	if strings.Join(summaryIDs, " ") != "68019954,68008607 68004314,68005600" {
+		t.Errorf("unexpected ESummary requests %q", summaryIDs)
+	}
+
+	parents, err := loc.Parents(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// F03.625's record is not in the fixture, so only one parent resolves.
+	if len(parents) != 1 || parents[0].TreeNumber != "C10.597.606" || parents[0].Name != "Neurodevelopmental Disorders" {
+		t.Errorf("unexpected parents %+v", parents)
+	}
+	if _, err := loc.Siblings(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if searches != 1 {
+		t.Errorf("expected the term to be searched once, got %d", searches)
+	}
+}
@@ -0,0 +1,404 @@
+package ncbi
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCacheMaxBytes is the default on-disk cache size cap (200 MB).
+	DefaultCacheMaxBytes int64 = 200 * 1024 * 1024
+	// DefaultCacheTTL applies to endpoints without an explicit TTL.
+	DefaultCacheTTL = 24 * time.Hour
+
+	// cacheMagic prefixes every entry file so foreign files are never served.
+	cacheMagic = "pubmed-cli-cache/1"
+	// cacheFileExt marks entry files inside the cache directory.
+	cacheFileExt = ".entry"
+)
+
+// DefaultCacheTTLs holds per-endpoint TTLs. Search results change as PubMed
+// indexes new records; article records and link graphs change far less often.
+// EPost creates history-server state on every call, so it is never cached.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"epost.fcgi":    0,
+	"esearch.fcgi":  1 * time.Hour,
+	"efetch.fcgi":   7 * 24 * time.Hour,
+	"esummary.fcgi": 7 * 24 * time.Hour,
+	"elink.fcgi":    24 * time.Hour,
+	"ecitmatch.cgi": 7 * 24 * time.Hour,
+}
+
+// errorBodyRe matches the error markers NCBI sends with HTTP 200: an
+// "error"/"ERROR" JSON key (top level, or inside esearchresult or a
+// document summary) or an <ERROR> XML element. Neither can occur inside
+// JSON string values or XML text, where quotes and angle brackets are
+// escaped.
+var errorBodyRe = regexp.MustCompile(`"(?:error|ERROR)"\s*:|<ERROR>`)
+
+// isErrorBody reports whether body is an NCBI error response that must not
+// be cached, since replaying it would outlast a temporary failure.
+func isErrorBody(body []byte) bool {
+	return errorBodyRe.Match(body)
+}
+
+// cacheExcludedParams are stripped before computing a cache key so that
+// entries are shared regardless of credentials or client identity.
+var cacheExcludedParams = []string{"api_key", "tool", "email"}
+
+// Cache is a content-addressed on-disk response cache for NCBI requests.
+// Entries are keyed on endpoint plus normalized query parameters, expire
+// after a per-endpoint TTL, and are evicted least-recently-used first once
+// the directory exceeds MaxBytes.
+type Cache struct {
+	Dir        string
+	MaxBytes   int64
+	DefaultTTL time.Duration
+	TTLs       map[string]time.Duration
+
+	mu   sync.Mutex
+	size int64 // bytes on disk; -1 until first scan
+	now  func() time.Time
+}
+
+// CacheStats summarizes the contents of a cache directory.
+type CacheStats struct {
+	Dir      string `json:"dir"`
+	Entries  int    `json:"entries"`
+	Bytes    int64  `json:"bytes"`
+	Expired  int    `json:"expired"`
+	MaxBytes int64  `json:"max_bytes"`
+}
+
+// cacheEntry describes a single entry file on disk.
+type cacheEntry struct {
+	path     string
+	size     int64
+	accessed time.Time
+}
+
+// NewCache creates a cache rooted at dir with default TTLs and size cap.
+// The directory is created lazily on first write.
+func NewCache(dir string) *Cache {
+	ttls := make(map[string]time.Duration, len(DefaultCacheTTLs))
+	for k, v := range DefaultCacheTTLs {
+		ttls[k] = v
+	}
+	return &Cache{
+		Dir:        dir,
+		MaxBytes:   DefaultCacheMaxBytes,
+		DefaultTTL: DefaultCacheTTL,
+		TTLs:       ttls,
+		size:       -1,
+		now:        time.Now,
+	}
+}
+
+// Key returns the cache key for a request. Parameters are normalized by
+// url.Values.Encode (sorted by name) after dropping api_key, tool and email.
+func (c *Cache) Key(endpoint string, params url.Values) string {
+	normalized := make(url.Values, len(params))
+	for k, v := range params {
+		normalized[k] = v
+	}
+	for _, k := range cacheExcludedParams {
+		normalized.Del(k)
+	}
+	sum := sha256.Sum256([]byte(endpoint + "?" + normalized.Encode()))
+	return hex.EncodeToString(sum[:])
+}
+
+// TTL returns the time-to-live for responses from endpoint. A TTL of zero
+// or less disables caching for that endpoint.
+func (c *Cache) TTL(endpoint string) time.Duration {
+	if ttl, ok := c.TTLs[endpoint]; ok {
+		return ttl
+	}
+	return c.DefaultTTL
+}
+
+// Get returns the cached body for key if present and not expired.
+// A hit refreshes the entry's access time for LRU eviction.
+func (c *Cache) Get(endpoint, key string) ([]byte, bool) {
+	ttl := c.TTL(endpoint)
+	if ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := c.entryPath(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	_, stored, body, err := decodeCacheEntry(data)
+	if err != nil {
+		return nil, false
+	}
+	if c.now().Sub(stored) > ttl {
+		return nil, false
+	}
+
+	now := c.now()
+	_ = os.Chtimes(path, now, now)
+	return body, true
+}
+
+// Put stores body under key and evicts old entries if the cache exceeds
+// MaxBytes. Responses from endpoints with caching disabled are not stored.
+func (c *Cache) Put(endpoint, key string, body []byte) error {
+	if c.TTL(endpoint) <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := c.entryPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	if c.size < 0 {
+		c.size = c.scanSize()
+	}
+	if info, err := os.Stat(path); err == nil {
+		c.size -= info.Size()
+	}
+
+	// A unique temp file keeps concurrent writers (other processes sharing
+	// the cache) from clobbering each other before the atomic rename.
+	data := encodeCacheEntry(endpoint, c.now(), body)
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+	c.size += int64(len(data))
+
+	if c.MaxBytes > 0 && c.size > c.MaxBytes {
+		if _, err := c.evictLocked(c.MaxBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats reports the number and size of entries, including expired ones.
+func (c *Cache) Stats() (CacheStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := CacheStats{Dir: c.Dir, MaxBytes: c.MaxBytes}
+	entries, err := c.listEntries()
+	if err != nil {
+		return stats, err
+	}
+	for _, e := range entries {
+		stats.Entries++
+		stats.Bytes += e.size
+		if c.expired(e.path) {
+			stats.Expired++
+		}
+	}
+	return stats, nil
+}
+
+// Clear removes every cache entry. The directory itself is kept.
+func (c *Cache) Clear() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.listEntries()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, e := range entries {
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("removing cache entry: %w", err)
+		}
+		removed++
+	}
+	c.size = 0
+	return removed, nil
+}
+
+// Prune removes expired entries and then evicts least-recently-used entries
+// until the cache fits within MaxBytes. It returns the number removed.
+func (c *Cache) Prune() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.listEntries()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, e := range entries {
+		if !c.expired(e.path) {
+			continue
+		}
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("removing cache entry: %w", err)
+		}
+		removed++
+	}
+
+	c.size = c.scanSize()
+	if c.MaxBytes > 0 && c.size > c.MaxBytes {
+		n, err := c.evictLocked(c.MaxBytes)
+		removed += n
+		if err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// evictLocked deletes least-recently-used entries until the cache holds at
+// most limit bytes. The caller must hold c.mu.
+func (c *Cache) evictLocked(limit int64) (int, error) {
+	entries, err := c.listEntries()
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].accessed.Before(entries[j].accessed)
+	})
+
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if total <= limit {
+			break
+		}
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("evicting cache entry: %w", err)
+		}
+		total -= e.size
+		removed++
+	}
+	c.size = total
+	return removed, nil
+}
+
+// expired reports whether the entry at path is older than its endpoint TTL.
+// Unreadable or malformed entries count as expired.
+func (c *Cache) expired(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return true
+	}
+	defer f.Close()
+
+	header, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil {
+		return true
+	}
+	endpoint, stored, _, err := decodeCacheEntry([]byte(header))
+	if err != nil {
+		return true
+	}
+	return c.now().Sub(stored) > c.TTL(endpoint)
+}
+
+func (c *Cache) listEntries() ([]cacheEntry, error) {
+	var entries []cacheEntry
+	err := filepath.WalkDir(c.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, cacheFileExt) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		entries = append(entries, cacheEntry{path: path, size: info.Size(), accessed: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading cache directory: %w", err)
+	}
+	return entries, nil
+}
+
+func (c *Cache) scanSize() int64 {
+	entries, err := c.listEntries()
+	if err != nil {
+		return 0
+	}
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+	return total
+}
+
+// entryPath shards entries by the first two hex digits of the key.
+func (c *Cache) entryPath(key string) string {
+	return filepath.Join(c.Dir, key[:2], key+cacheFileExt)
+}
+
+// encodeCacheEntry prepends a one-line header recording the endpoint and
+// storage time to the raw response body.
+func encodeCacheEntry(endpoint string, stored time.Time, body []byte) []byte {
+	header := fmt.Sprintf("%s %d %s\n", cacheMagic, stored.Unix(), endpoint)
+	data := make([]byte, 0, len(header)+len(body))
+	data = append(data, header...)
+	return append(data, body...)
+}
+
+func decodeCacheEntry(data []byte) (string, time.Time, []byte, error) {
+	i := bytes.IndexByte(data, '\n')
+	if i < 0 {
+		return "", time.Time{}, nil, fmt.Errorf("malformed cache entry")
+	}
+	fields := strings.Fields(string(data[:i]))
+	if len(fields) != 3 || fields[0] != cacheMagic {
+		return "", time.Time{}, nil, fmt.Errorf("malformed cache entry header")
+	}
+	secs, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return "", time.Time{}, nil, fmt.Errorf("malformed cache entry timestamp: %w", err)
+	}
+	return fields[2], time.Unix(secs, 0), data[i+1:], nil
+}
@@ -0,0 +1,276 @@
+package ncbi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheKey_IgnoresCredentials(t *testing.T) {
+	c := NewCache(t.TempDir())
+
+	a := url.Values{"db": {"pubmed"}, "id": {"1,2"}, "api_key": {"one"}, "tool": {"x"}}
+	b := url.Values{"id": {"1,2"}, "db": {"pubmed"}, "api_key": {"two"}, "email": {"y@example.com"}}
+	if c.Key("efetch.fcgi", a) != c.Key("efetch.fcgi", b) {
+		t.Error("expected keys to match when only api_key/tool/email differ")
+	}
+	if c.Key("efetch.fcgi", a) == c.Key("esummary.fcgi", a) {
+		t.Error("expected keys to differ across endpoints")
+	}
+	if a.Get("api_key") != "one" {
+		t.Error("Key must not mutate the caller's params")
+	}
+}
+
+func TestCache_PutGet(t *testing.T) {
+	c := NewCache(t.TempDir())
+	key := c.Key("efetch.fcgi", url.Values{"id": {"123"}})
+
+	if _, ok := c.Get("efetch.fcgi", key); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+	if err := c.Put("efetch.fcgi", key, []byte("<xml/>")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, ok := c.Get("efetch.fcgi", key)
+	if !ok {
+		t.Fatal("expected hit after Put")
+	}
+	if string(body) != "<xml/>" {
+		t.Errorf("expected body %q, got %q", "<xml/>", string(body))
+	}
+}
+
+func TestCache_TTLExpiry(t *testing.T) {
+	c := NewCache(t.TempDir())
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	key := c.Key("esearch.fcgi", url.Values{"term": {"asthma"}})
+	if err := c.Put("esearch.fcgi", key, []byte("{}")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now = now.Add(c.TTL("esearch.fcgi") + time.Second)
+	if _, ok := c.Get("esearch.fcgi", key); ok {
+		t.Error("expected expired entry to miss")
+	}
+
+	stats, err := c.Stats()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Entries != 1 || stats.Expired != 1 {
+		t.Errorf("expected 1 entry / 1 expired, got %d / %d", stats.Entries, stats.Expired)
+	}
+
+	removed, err := c.Prune()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("expected prune to remove 1 entry, got %d", removed)
+	}
+}
+
+func TestCache_ZeroTTLDisablesCaching(t *testing.T) {
+	c := NewCache(t.TempDir())
+
+	key := c.Key("epost.fcgi", url.Values{"id": {"1,2,3"}})
+	if err := c.Put("epost.fcgi", key, []byte("<ePostResult/>")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := c.Get("epost.fcgi", key); ok {
+		t.Error("expected epost responses never to be cached")
+	}
+	if stats, _ := c.Stats(); stats.Entries != 0 {
+		t.Errorf("expected no entries written, got %d", stats.Entries)
+	}
+}
+
+func TestCache_LRUEviction(t *testing.T) {
+	c := NewCache(t.TempDir())
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	body := []byte(strings.Repeat("x", 100))
+	keys := make([]string, 3)
+	for i := range keys {
+		keys[i] = c.Key("efetch.fcgi", url.Values{"id": {string(rune('a' + i))}})
+		if err := c.Put("efetch.fcgi", keys[i], body); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		now = now.Add(time.Second)
+	}
+
+	// Touch the oldest entry so the second one becomes least recently used.
+	if _, ok := c.Get("efetch.fcgi", keys[0]); !ok {
+		t.Fatal("expected hit for first entry")
+	}
+	now = now.Add(time.Second)
+
+	entrySize := int64(len(encodeCacheEntry("efetch.fcgi", now, body)))
+	c.MaxBytes = 3 * entrySize
+	fourth := c.Key("efetch.fcgi", url.Values{"id": {"d"}})
+	if err := c.Put("efetch.fcgi", fourth, body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := c.Get("efetch.fcgi", keys[1]); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	for _, k := range []string{keys[0], keys[2], fourth} {
+		if _, ok := c.Get("efetch.fcgi", k); !ok {
+			t.Errorf("expected entry %s to survive eviction", k[:8])
+		}
+	}
+}
+
+func TestCache_Clear(t *testing.T) {
+	c := NewCache(t.TempDir())
+	for _, id := range []string{"1", "2"} {
+		key := c.Key("efetch.fcgi", url.Values{"id": {id}})
+		if err := c.Put("efetch.fcgi", key, []byte("body")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	removed, err := c.Clear()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("expected 2 removed, got %d", removed)
+	}
+	stats, _ := c.Stats()
+	if stats.Entries != 0 {
+		t.Errorf("expected empty cache after Clear, got %d entries", stats.Entries)
+	}
+}
+
+func TestDoGet_CacheHitSkipsNetwork(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`cached body`))
+	}))
+	defer srv.Close()
+
+	c := NewBaseClient(
+		WithBaseURL(srv.URL),
+		WithAPIKey("test"),
+		WithCache(NewCache(t.TempDir())),
+	)
+
+	for i := 0; i < 2; i++ {
+		body, err := c.DoGet(context.Background(), "efetch.fcgi", url.Values{"id": {"123"}})
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+		if string(body) != "cached body" {
+			t.Errorf("request %d: expected %q, got %q", i, "cached body", string(body))
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 network call, got %d", got)
+	}
+}
+
+func TestDoGet_CacheSkipsErrors(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := NewBaseClient(
+		WithBaseURL(srv.URL),
+		WithAPIKey("test"),
+		WithCache(NewCache(t.TempDir())),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.DoGet(context.Background(), "efetch.fcgi", url.Values{"id": {"123"}}); err == nil {
+			t.Fatalf("request %d: expected error", i)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected failed responses not to be cached (2 calls), got %d", got)
+	}
+}
+
+func TestDoGet_CacheSkipsErrorBodies(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"error":"API rate limit exceeded","count":"11"}`))
+	}))
+	defer srv.Close()
+
+	c := NewBaseClient(
+		WithBaseURL(srv.URL),
+		WithAPIKey("test"),
+		WithCache(NewCache(t.TempDir())),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.DoGet(context.Background(), "esearch.fcgi", url.Values{"term": {"asthma"}}); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected error bodies not to be cached (2 calls), got %d", got)
+	}
+}
+
+func TestIsErrorBody(t *testing.T) {
+	tests := map[string]bool{
+		`{"error":"API rate limit exceeded"}`:                            true,
+		`{"esearchresult":{"ERROR":"Invalid query"}}`:                    true,
+		`<eFetchResult><ERROR>Empty id list</ERROR></eFetchResult>`:      true,
+		`{"result":{"1":{"title":"Trial \"error\": a review"}}}`:         false,
+		`<ArticleTitle>&lt;ERROR&gt; handling in clinics</ArticleTitle>`: false,
+		`{"esearchresult":{"count":"1","idlist":["123"]}}`:               false,
+	}
+	for body, want := range tests {
+		if got := isErrorBody([]byte(body)); got != want {
+			t.Errorf("isErrorBody(%s) = %v, want %v", body, got, want)
+		}
+	}
+}
+
+func TestCache_ConcurrentPut(t *testing.T) {
+	dir := t.TempDir()
+	key := NewCache(dir).Key("efetch.fcgi", url.Values{"id": {"123"}})
+
+	// Separate Cache values stand in for separate processes, which do not
+	// share a mutex.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := NewCache(dir).Put("efetch.fcgi", key, []byte("<xml/>")); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if body, ok := NewCache(dir).Get("efetch.fcgi", key); !ok || string(body) != "<xml/>" {
+		t.Errorf("expected intact entry, got %q, %v", body, ok)
+	}
+	tmps, _ := filepath.Glob(filepath.Join(dir, key[:2], "*.tmp"))
+	if len(tmps) != 0 {
+		t.Errorf("expected no leftover temp files, got %v", tmps)
+	}
+}
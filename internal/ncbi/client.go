@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -30,11 +31,6 @@ const (
 
 	// DefaultMaxResponseBytes is the maximum response body size (50 MB).
 	DefaultMaxResponseBytes int64 = 50 * 1024 * 1024
-
-	// Retry policy for transient rate-limit responses.
-	ncbiMaxRetries    = 2
-	ncbiBaseRetryWait = 700 * time.Millisecond
-	ncbiMaxRetryWait  = 4 * time.Second
 )
 
 // BaseClient is a shared HTTP client for NCBI E-utilities with proper
@@ -47,6 +43,9 @@ type BaseClient struct {
 	HTTPClient *http.Client
 	Limiter    *rate.Limiter
 	MaxBytes   int64
+	Cache      *Cache      // optional on-disk response cache; nil disables caching
+	Retry      RetryPolicy // retry behavior for transient failures
+	Logger     *log.Logger // optional debug log for retries; nil disables logging
 }
 
 // Option configures a BaseClient.
@@ -87,6 +86,16 @@ func WithMaxResponseBytes(n int64) Option {
 	return func(c *BaseClient) { c.MaxBytes = n }
 }
 
+// WithCache enables the on-disk response cache. A nil cache disables caching.
+func WithCache(cache *Cache) Option {
+	return func(c *BaseClient) { c.Cache = cache }
+}
+
+// WithLogger sets a debug logger that records retry attempts.
+func WithLogger(l *log.Logger) Option {
+	return func(c *BaseClient) { c.Logger = l }
+}
+
 // NewBaseClient creates a new NCBI base client with the given options.
 func NewBaseClient(opts ...Option) *BaseClient {
 	c := &BaseClient{
@@ -95,6 +104,7 @@ func NewBaseClient(opts ...Option) *BaseClient {
 		Email:    DefaultEmail,
 		MaxBytes: DefaultMaxResponseBytes,
 		Limiter:  rate.NewLimiter(rate.Limit(RateWithoutKey), 1),
+		Retry:    DefaultRetryPolicy(),
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -107,8 +117,103 @@ func NewBaseClient(opts ...Option) *BaseClient {
 
 // DoGet performs a rate-limited GET request with common NCBI parameters
 // and response size limits. Returns the response body.
+// When a Cache is configured, fresh cached responses are returned without
+// touching the network and successful responses are stored; bodies that
+// carry an NCBI error despite HTTP 200 are not.
 func (c *BaseClient) DoGet(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
-	// Add common NCBI params once per request.
+	return c.do(ctx, http.MethodGet, endpoint, params)
+}
+
+// DoPost performs a rate-limited POST request with params sent as an
+// application/x-www-form-urlencoded body. NCBI recommends POST when a
+// request carries more IDs than fit comfortably in a URL. Caching, retries
+// and size limits behave exactly as in DoGet.
+func (c *BaseClient) DoPost(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
+	return c.do(ctx, http.MethodPost, endpoint, params)
+}
+
+// DoStream performs a rate-limited request like DoGet (method GET) or DoPost
+// (method POST) but returns the response body unread so large payloads can
+// be decoded incrementally. Streamed responses bypass the cache and the
+// MaxBytes guard; retries cover only failures before the body is returned.
+// The caller must close the returned body.
+func (c *BaseClient) DoStream(ctx context.Context, method, endpoint string, params url.Values) (io.ReadCloser, error) {
+	u, encoded, err := c.prepare(endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, endpoint, u, encoded, attempt)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			continue
+		}
+		if attempt > 0 {
+			c.debugf("%s succeeded after %d retries", endpoint, attempt)
+		}
+		return resp.Body, nil
+	}
+}
+
+func (c *BaseClient) do(ctx context.Context, method, endpoint string, params url.Values) ([]byte, error) {
+	var cacheKey string
+	if c.Cache != nil {
+		cacheKey = c.Cache.Key(endpoint, params)
+		if body, ok := c.Cache.Get(endpoint, cacheKey); ok {
+			return body, nil
+		}
+	}
+
+	u, encoded, err := c.prepare(endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, endpoint, u, encoded, attempt)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			continue
+		}
+
+		// Guard against unbounded reads: read up to MaxBytes+1 to detect oversized responses.
+		r := io.LimitReader(resp.Body, c.MaxBytes+1)
+		body, err := io.ReadAll(r)
+		resp.Body.Close()
+		if err != nil {
+			if c.shouldRetryNetError(ctx, err, attempt) {
+				if err := c.waitRetry(ctx, endpoint, attempt, 0, err.Error()); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, fmt.Errorf("reading response: %w", err)
+		}
+		if int64(len(body)) > c.MaxBytes {
+			return nil, fmt.Errorf("response exceeds maximum size of %d bytes", c.MaxBytes)
+		}
+
+		if attempt > 0 {
+			c.debugf("%s succeeded after %d retries", endpoint, attempt)
+		}
+
+		if c.Cache != nil && !isErrorBody(body) {
+			// A failed cache write must not fail the request.
+			_ = c.Cache.Put(endpoint, cacheKey, body)
+		}
+
+		return body, nil
+	}
+}
+
+// prepare adds the common NCBI params and returns the endpoint URL and the
+// encoded parameters.
+func (c *BaseClient) prepare(endpoint string, params url.Values) (string, string, error) {
 	if c.APIKey != "" {
 		params.Set("api_key", c.APIKey)
 	}
@@ -121,68 +226,100 @@ func (c *BaseClient) DoGet(ctx context.Context, endpoint string, params url.Valu
 
 	u, err := url.JoinPath(c.BaseURL, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("building URL: %w", err)
+		return "", "", fmt.Errorf("building URL: %w", err)
 	}
-	fullURL := u + "?" + params.Encode()
+	return u, params.Encode(), nil
+}
 
-	for attempt := 0; attempt <= ncbiMaxRetries; attempt++ {
-		// Wait for rate limiter token (respects context cancellation).
-		if err := c.Limiter.Wait(ctx); err != nil {
-			return nil, fmt.Errorf("rate limit wait: %w", err)
-		}
+// send makes one attempt at a request. It returns the response when NCBI
+// answered 200 OK, or a nil response (after waiting out the backoff) when
+// the attempt failed transiently and should be retried.
+func (c *BaseClient) send(ctx context.Context, method, endpoint, u, encoded string, attempt int) (*http.Response, error) {
+	// Wait for rate limiter token (respects context cancellation).
+	if err := c.Limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit wait: %w", err)
+	}
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
-		if err != nil {
-			return nil, fmt.Errorf("creating request: %w", err)
-		}
+	req, err := newRequest(ctx, method, u, encoded)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
 
-		resp, err := c.HTTPClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("executing request: %w", err)
+	policy := c.Retry
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		if c.shouldRetryNetError(ctx, err, attempt) {
+			if err := c.waitRetry(ctx, endpoint, attempt, 0, err.Error()); err != nil {
+				return nil, err
+			}
+			return nil, nil
 		}
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
 
-		if resp.StatusCode == http.StatusTooManyRequests {
-			if attempt >= ncbiMaxRetries {
-				resp.Body.Close()
-				return nil, fmt.Errorf("NCBI rate limit exceeded (HTTP 429 after %d retries). Consider using an API key with --api-key or NCBI_API_KEY env var", ncbiMaxRetries)
-			}
+	if resp.StatusCode != http.StatusOK && policy.retryableStatus(resp.StatusCode) {
+		retryAfter := retryAfterDuration(resp.Header.Get("Retry-After"))
+		resp.Body.Close()
 
-			retryAfter := retryAfterDuration(resp.Header.Get("Retry-After"))
-			resp.Body.Close()
-			if retryAfter <= 0 {
-				// Exponential backoff with cap.
-				retryAfter = ncbiBaseRetryWait * time.Duration(1<<attempt)
-				if retryAfter > ncbiMaxRetryWait {
-					retryAfter = ncbiMaxRetryWait
-				}
-			}
-			if err := sleepWithContext(ctx, retryAfter); err != nil {
-				return nil, fmt.Errorf("rate limit retry canceled: %w", err)
+		if attempt >= policy.MaxRetries {
+			if resp.StatusCode == http.StatusTooManyRequests {
+				return nil, fmt.Errorf("NCBI rate limit exceeded (HTTP 429 after %d retries). Consider using an API key with --api-key or NCBI_API_KEY env var", attempt)
 			}
-
-			continue
+			return nil, fmt.Errorf("NCBI returned HTTP %d for %s after %d retries", resp.StatusCode, endpoint, attempt)
 		}
 
-		if resp.StatusCode != http.StatusOK {
-			defer resp.Body.Close()
-			return nil, fmt.Errorf("NCBI returned HTTP %d for %s", resp.StatusCode, endpoint)
+		reason := fmt.Sprintf("HTTP %d", resp.StatusCode)
+		if err := c.waitRetry(ctx, endpoint, attempt, retryAfter, reason); err != nil {
+			return nil, err
 		}
+		return nil, nil
+	}
 
-		// Guard against unbounded reads: read up to MaxBytes+1 to detect oversized responses.
-		r := io.LimitReader(resp.Body, c.MaxBytes+1)
-		body, err := io.ReadAll(r)
+	if resp.StatusCode != http.StatusOK {
 		resp.Body.Close()
+		return nil, fmt.Errorf("NCBI returned HTTP %d for %s", resp.StatusCode, endpoint)
+	}
+
+	return resp, nil
+}
+
+// newRequest builds a GET with encoded params in the query string, or a
+// POST with them as a form body. The body is rebuilt on every attempt.
+func newRequest(ctx context.Context, method, u, encoded string) (*http.Request, error) {
+	if method == http.MethodPost {
+		req, err := http.NewRequestWithContext(ctx, method, u, strings.NewReader(encoded))
 		if err != nil {
-			return nil, fmt.Errorf("reading response: %w", err)
-		}
-		if int64(len(body)) > c.MaxBytes {
-			return nil, fmt.Errorf("response exceeds maximum size of %d bytes", c.MaxBytes)
+			return nil, err
 		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req, nil
+	}
+	return http.NewRequestWithContext(ctx, method, u+"?"+encoded, nil)
+}
 
-		return body, nil
+// shouldRetryNetError reports whether a transport-level error is worth
+// retrying under the client's policy. Cancellation is never retried.
+func (c *BaseClient) shouldRetryNetError(ctx context.Context, err error, attempt int) bool {
+	if ctx.Err() != nil {
+		return false
 	}
+	return c.Retry.RetryNetErrors && attempt < c.Retry.MaxRetries && isTransientNetError(err)
+}
 
-	return nil, fmt.Errorf("unreachable request loop")
+// waitRetry logs and sleeps before the next attempt, honoring cancellation.
+func (c *BaseClient) waitRetry(ctx context.Context, endpoint string, attempt int, retryAfter time.Duration, reason string) error {
+	wait := c.Retry.backoff(attempt, retryAfter)
+	c.debugf("retry %d/%d for %s after %s (waiting %s)", attempt+1, c.Retry.MaxRetries, endpoint, reason, wait.Round(time.Millisecond))
+	if err := sleepWithContext(ctx, wait); err != nil {
+		return fmt.Errorf("retry canceled: %w", err)
+	}
+	return nil
+}
+
+func (c *BaseClient) debugf(format string, args ...any) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, args...)
+	}
 }
 
 func retryAfterDuration(v string) time.Duration {
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -339,3 +341,75 @@ func TestDoGet_URLJoinPath(t *testing.T) {
 
 	fmt.Println("received path:", receivedPath)
 }
+
+func TestDoPost_FormBody(t *testing.T) {
+	var (
+		method      string
+		contentType string
+		rawQuery    string
+		form        url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		contentType = r.Header.Get("Content-Type")
+		rawQuery = r.URL.RawQuery
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("parsing form: %v", err)
+		}
+		form = r.PostForm
+		w.Write([]byte(`OK`))
+	}))
+	defer srv.Close()
+
+	c := NewBaseClient(WithBaseURL(srv.URL), WithAPIKey("my-api-key"))
+	body, err := c.DoPost(context.Background(), "efetch.fcgi", url.Values{"id": {"1,2,3"}, "db": {"pubmed"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != "OK" {
+		t.Errorf("expected OK, got %q", string(body))
+	}
+
+	if method != http.MethodPost {
+		t.Errorf("expected POST, got %s", method)
+	}
+	if contentType != "application/x-www-form-urlencoded" {
+		t.Errorf("expected form content type, got %q", contentType)
+	}
+	if rawQuery != "" {
+		t.Errorf("expected no query string, got %q", rawQuery)
+	}
+	if form.Get("id") != "1,2,3" {
+		t.Errorf("expected id=1,2,3 in body, got %q", form.Get("id"))
+	}
+	if form.Get("api_key") != "my-api-key" {
+		t.Errorf("expected api_key in body, got %q", form.Get("api_key"))
+	}
+}
+
+func TestDoPost_RetriesResendBody(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		r.ParseForm()
+		if r.PostForm.Get("id") != "42" {
+			t.Errorf("attempt %d: expected id=42 in body, got %q", n, r.PostForm.Get("id"))
+		}
+		if n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`OK`))
+	}))
+	defer srv.Close()
+
+	p := DefaultRetryPolicy()
+	p.BaseWait = time.Millisecond
+	c := NewBaseClient(WithBaseURL(srv.URL), WithAPIKey("test"), WithRetryPolicy(p))
+	if _, err := c.DoPost(context.Background(), "efetch.fcgi", url.Values{"id": {"42"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
@@ -0,0 +1,102 @@
+package ncbi
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how requests are retried after transient failures.
+type RetryPolicy struct {
+	MaxRetries     int           // Retries after the first attempt; 0 disables retrying
+	BaseWait       time.Duration // Backoff before the first retry, doubled per attempt
+	MaxWait        time.Duration // Cap on a single wait, including Retry-After
+	RetryStatuses  []int         // HTTP status codes treated as transient
+	RetryNetErrors bool          // Retry connection resets, refusals, timeouts and EOFs
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured:
+// two retries on HTTP 429/502/503/504 and transient network errors, with
+// jittered exponential backoff starting at 700ms and capped at 4s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 2,
+		BaseWait:   700 * time.Millisecond,
+		MaxWait:    4 * time.Second,
+		RetryStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetErrors: true,
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *BaseClient) { c.Retry = p }
+}
+
+func (p RetryPolicy) retryableStatus(code int) bool {
+	for _, s := range p.RetryStatuses {
+		if s == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the wait before retry number attempt+1. A positive
+// retryAfter (from the Retry-After header) is honored up to MaxWait;
+// otherwise the wait is exponential with "equal jitter" so concurrent
+// clients spread out.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	limit := p.MaxWait
+	if limit <= 0 {
+		limit = math.MaxInt64
+	}
+	if retryAfter > 0 {
+		return min(retryAfter, limit)
+	}
+	// Compare before shifting: BaseWait<<attempt overflows for large
+	// --max-retries, which would otherwise retry with no wait at all.
+	d := limit
+	if attempt < 62 && p.BaseWait <= limit>>attempt {
+		d = p.BaseWait << attempt
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// isTransientNetError reports whether err looks like a network failure that
+// may succeed on retry. Context cancellation must be checked by the caller.
+func isTransientNetError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,269 @@
+package ncbi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy keeps retry tests quick while exercising the real paths.
+func fastRetryPolicy() RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.BaseWait = time.Millisecond
+	p.MaxWait = 5 * time.Millisecond
+	return p
+}
+
+func TestDoGet_Retries5xxThenSucceeds(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("OK"))
+	}))
+	defer srv.Close()
+
+	var logBuf bytes.Buffer
+	c := NewBaseClient(
+		WithBaseURL(srv.URL),
+		WithAPIKey("test"),
+		WithRetryPolicy(fastRetryPolicy()),
+		WithLogger(log.New(&logBuf, "", 0)),
+	)
+
+	body, err := c.DoGet(context.Background(), "test.fcgi", make(map[string][]string))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != "OK" {
+		t.Errorf("expected OK, got %q", string(body))
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+
+	logged := logBuf.String()
+	if !strings.Contains(logged, "retry 1/2 for test.fcgi after HTTP 503") {
+		t.Errorf("expected retry in debug log, got %q", logged)
+	}
+	if !strings.Contains(logged, "succeeded after 2 retries") {
+		t.Errorf("expected success summary in debug log, got %q", logged)
+	}
+}
+
+func TestDoGet_RetriesExhausted(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c := NewBaseClient(WithBaseURL(srv.URL), WithAPIKey("test"), WithRetryPolicy(fastRetryPolicy()))
+	_, err := c.DoGet(context.Background(), "test.fcgi", make(map[string][]string))
+	if err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if !strings.Contains(err.Error(), "HTTP 502") || !strings.Contains(err.Error(), "after 2 retries") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestDoGet_NonRetryableStatus(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := NewBaseClient(WithBaseURL(srv.URL), WithAPIKey("test"), WithRetryPolicy(fastRetryPolicy()))
+	if _, err := c.DoGet(context.Background(), "test.fcgi", make(map[string][]string)); err == nil {
+		t.Fatal("expected error for HTTP 400")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 call for non-retryable status, got %d", got)
+	}
+}
+
+func TestDoGet_RetriesConnectionReset(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// Drop the connection without a response.
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		w.Write([]byte("OK"))
+	}))
+	defer srv.Close()
+
+	c := NewBaseClient(WithBaseURL(srv.URL), WithAPIKey("test"), WithRetryPolicy(fastRetryPolicy()))
+	body, err := c.DoGet(context.Background(), "test.fcgi", make(map[string][]string))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != "OK" {
+		t.Errorf("expected OK, got %q", string(body))
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestDoGet_RetryDisabled(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	p := fastRetryPolicy()
+	p.MaxRetries = 0
+	c := NewBaseClient(WithBaseURL(srv.URL), WithAPIKey("test"), WithRetryPolicy(p))
+	if _, err := c.DoGet(context.Background(), "test.fcgi", make(map[string][]string)); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 call with retries disabled, got %d", got)
+	}
+}
+
+func TestDoGet_RetryCancelledDuringBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	p := DefaultRetryPolicy()
+	p.BaseWait = time.Minute
+	p.MaxWait = time.Minute
+	c := NewBaseClient(WithBaseURL(srv.URL), WithAPIKey("test"), WithRetryPolicy(p))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.DoGet(ctx, "test.fcgi", make(map[string][]string))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("backoff did not honor cancellation (took %v)", elapsed)
+	}
+}
+
+func TestRetryPolicy_BackoffBounds(t *testing.T) {
+	p := RetryPolicy{BaseWait: 100 * time.Millisecond, MaxWait: 300 * time.Millisecond}
+	for attempt := 0; attempt < 5; attempt++ {
+		full := p.BaseWait * time.Duration(1<<attempt)
+		if full > p.MaxWait {
+			full = p.MaxWait
+		}
+		for i := 0; i < 20; i++ {
+			d := p.backoff(attempt, 0)
+			if d < full/2 || d > full {
+				t.Fatalf("attempt %d: backoff %v outside [%v, %v]", attempt, d, full/2, full)
+			}
+		}
+	}
+	if d := p.backoff(0, 200*time.Millisecond); d != 200*time.Millisecond {
+		t.Errorf("expected Retry-After to be honored, got %v", d)
+	}
+	if d := p.backoff(0, 3*time.Hour); d != p.MaxWait {
+		t.Errorf("expected Retry-After to be capped at %v, got %v", p.MaxWait, d)
+	}
+}
+
+func TestRetryPolicy_BackoffLargeAttempt(t *testing.T) {
+	p := DefaultRetryPolicy()
+	for _, attempt := range []int{35, 62, 63, 100} {
+		if d := p.backoff(attempt, 0); d < p.MaxWait/2 || d > p.MaxWait {
+			t.Errorf("attempt %d: backoff %v outside [%v, %v]", attempt, d, p.MaxWait/2, p.MaxWait)
+		}
+	}
+	// Without a cap the wait must still grow rather than wrap to zero.
+	p.MaxWait = 0
+	if d := p.backoff(100, 0); d < math.MaxInt64/2 {
+		t.Errorf("uncapped backoff overflowed to %v", d)
+	}
+}
+
+func TestIsTransientNetError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{io.EOF, true},
+		{syscall.ECONNRESET, true},
+		{syscall.ECONNREFUSED, true},
+		{errors.New("malformed HTTP response"), false},
+	}
+	for _, tt := range tests {
+		if got := isTransientNetError(tt.err); got != tt.want {
+			t.Errorf("isTransientNetError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestDoStream_RetriesThenStreamsUncapped(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(strings.Repeat("X", 2048)))
+	}))
+	defer srv.Close()
+
+	c := NewBaseClient(
+		WithBaseURL(srv.URL),
+		WithAPIKey("test"),
+		WithRetryPolicy(fastRetryPolicy()),
+		WithMaxResponseBytes(1024),
+		WithCache(NewCache(t.TempDir())),
+	)
+
+	for i := 0; i < 2; i++ {
+		body, err := c.DoStream(context.Background(), http.MethodGet, "efetch.fcgi", url.Values{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, err := io.ReadAll(body)
+		body.Close()
+		if err != nil {
+			t.Fatalf("reading stream: %v", err)
+		}
+		// Streams are not subject to MaxBytes.
+		if len(data) != 2048 {
+			t.Errorf("expected 2048 bytes, got %d", len(data))
+		}
+	}
+
+	// One retried 503, then two uncached streamed requests.
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
@@ -8,11 +8,12 @@ import (
 	"strings"
 
 	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/idconv"
 	"github.com/henrybloomingdale/pubmed-cli/internal/mesh"
 )
 
 // writeSearchCSV exports search results to CSV.
-// If articles are provided, writes: PMID,Title,Year,Journal,DOI,Type.
+// If articles are provided, writes: PMID,Title,Year,Journal,DOI,Type,PubDate.
 // Otherwise writes: Rank,PMID.
 func writeSearchCSV(path string, result *eutils.SearchResult, articles []eutils.Article) error {
 	w, f, err := createCSV(path)
@@ -23,7 +24,7 @@ func writeSearchCSV(path string, result *eutils.SearchResult, articles []eutils.
 
 	if len(articles) > 0 {
 		// Rich CSV with article details
-		w.Write([]string{"PMID", "Title", "Year", "Journal", "DOI", "Type"})
+		w.Write([]string{"PMID", "Title", "Year", "Journal", "DOI", "Type", "PubDate"})
 
 		// Index articles by PMID for lookup
 		byPMID := make(map[string]eutils.Article, len(articles))
@@ -34,7 +35,7 @@ func writeSearchCSV(path string, result *eutils.SearchResult, articles []eutils.
 		for _, id := range result.IDs {
 			a, ok := byPMID[id]
 			if !ok {
-				w.Write([]string{id, "", "", "", "", ""})
+				w.Write([]string{id, "", "", "", "", "", ""})
 				continue
 			}
 			w.Write([]string{
@@ -44,6 +45,7 @@ func writeSearchCSV(path string, result *eutils.SearchResult, articles []eutils.
 				a.Journal,
 				a.DOI,
 				strings.Join(a.PublicationTypes, "; "),
+				a.PubDate.ISO(),
 			})
 		}
 	} else {
@@ -58,8 +60,14 @@ func writeSearchCSV(path string, result *eutils.SearchResult, articles []eutils.
 	return w.Error()
 }
 
+// articleCSVHeader lists the columns written by writeArticlesCSV and the
+// streaming CSV writer.
+var articleCSVHeader = []string{"PMID", "Title", "Authors", "Journal", "Year", "DOI", "Abstract", "MeSH",
+	"Keywords", "Grants", "AuthorAffiliations", "ORCIDs", "COI", "PubDate", "EPubDate"}
+
 // writeArticlesCSV exports article details to CSV.
-// Columns: PMID,Title,Authors,Journal,Year,DOI,Abstract,MeSH
+// Columns: PMID,Title,Authors,Journal,Year,DOI,Abstract,MeSH,Keywords,
+// Grants,AuthorAffiliations,ORCIDs,COI,PubDate,EPubDate
 func writeArticlesCSV(path string, articles []eutils.Article) error {
 	w, f, err := createCSV(path)
 	if err != nil {
@@ -67,41 +75,52 @@ func writeArticlesCSV(path string, articles []eutils.Article) error {
 	}
 	defer f.Close()
 
-	w.Write([]string{"PMID", "Title", "Authors", "Journal", "Year", "DOI", "Abstract", "MeSH"})
-
+	w.Write(articleCSVHeader)
 	for _, a := range articles {
-		// Authors: semicolon-separated full names
-		names := make([]string, len(a.Authors))
-		for i, au := range a.Authors {
-			names[i] = au.FullName()
-		}
-
-		// MeSH: semicolon-separated, major topics prefixed with *
-		meshTerms := make([]string, len(a.MeSHTerms))
-		for i, m := range a.MeSHTerms {
-			if m.MajorTopic {
-				meshTerms[i] = "*" + m.Descriptor
-			} else {
-				meshTerms[i] = m.Descriptor
-			}
-		}
-
-		w.Write([]string{
-			a.PMID,
-			a.Title,
-			strings.Join(names, "; "),
-			a.Journal,
-			a.Year,
-			a.DOI,
-			a.Abstract,
-			strings.Join(meshTerms, "; "),
-		})
+		w.Write(articleCSVRow(a))
 	}
 
 	w.Flush()
 	return w.Error()
 }
 
+// articleCSVRow formats one article as a row matching articleCSVHeader.
+func articleCSVRow(a eutils.Article) []string {
+	// Authors: semicolon-separated full names
+	names := make([]string, len(a.Authors))
+	for i, au := range a.Authors {
+		names[i] = au.FullName()
+	}
+
+	// MeSH: semicolon-separated, major topics prefixed with *
+	meshTerms := make([]string, len(a.MeSHTerms))
+	for i, m := range a.MeSHTerms {
+		if m.Major() {
+			meshTerms[i] = "*" + m.Descriptor
+		} else {
+			meshTerms[i] = m.Descriptor
+		}
+	}
+
+	return []string{
+		a.PMID,
+		a.Title,
+		strings.Join(names, "; "),
+		a.Journal,
+		a.Year,
+		a.DOI,
+		a.Abstract,
+		strings.Join(meshTerms, "; "),
+		strings.Join(a.Keywords, "; "),
+		strings.Join(grantLabels(a.Grants), "; "),
+		strings.Join(authorAffiliations(a.Authors), "; "),
+		strings.Join(authorORCIDs(a.Authors), "; "),
+		a.COIStatement,
+		a.PubDate.ISO(),
+		a.PubDate.Electronic,
+	}
+}
+
 // writeLinksCSV exports link results to CSV.
 // Columns: PMID,Score
 func writeLinksCSV(path string, result *eutils.LinkResult) error {
@@ -125,8 +144,74 @@ func writeLinksCSV(path string, result *eutils.LinkResult) error {
 	return w.Error()
 }
 
+// writeLinkResultsCSV exports link results for several sources to CSV.
+// Columns: Source,PMID,Score
+func writeLinkResultsCSV(path string, results []eutils.LinkResult) error {
+	w, f, err := createCSV(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Write([]string{"Source", "PMID", "Score"})
+	for _, r := range results {
+		for _, link := range r.Links {
+			score := ""
+			if link.Score > 0 {
+				score = strconv.Itoa(link.Score)
+			}
+			w.Write([]string{r.SourceID, link.ID, score})
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// writeAggregatedLinksCSV exports aggregated links to CSV.
+// Columns: PMID,Count,Sources
+func writeAggregatedLinksCSV(path string, links []eutils.AggregatedLink) error {
+	w, f, err := createCSV(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Write([]string{"PMID", "Count", "Sources"})
+	for _, link := range links {
+		w.Write([]string{link.ID, strconv.Itoa(link.Count), strings.Join(link.Sources, "; ")})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// writeLinkSetsCSV exports cross-database link results to CSV, one row per
+// link.
+// Columns: Source,LinkName,DbTo,ID,Accession,Title,Organism
+func writeLinkSetsCSV(path string, sets []eutils.LinkSet, records map[string]eutils.RecordSummary) error {
+	w, f, err := createCSV(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Write([]string{"Source", "LinkName", "DbTo", "ID", "Accession", "Title", "Organism"})
+	for _, set := range sets {
+		for _, db := range set.LinkSetDBs {
+			for _, l := range db.Links {
+				r := records[l.ID]
+				w.Write([]string{set.SourceID, db.LinkName, db.DbTo, l.ID, r.Accession, r.Title, r.Organism})
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
 // writeMeSHCSV exports a MeSH record to CSV.
-// Columns: UI,Name,ScopeNote,TreeNumbers,EntryTerms,Annotation
+// Columns: UI,Name,ScopeNote,TreeNumbers,EntryTerms,Annotation,AllowableQualifiers
 func writeMeSHCSV(path string, record *mesh.MeSHRecord) error {
 	w, f, err := createCSV(path)
 	if err != nil {
@@ -134,7 +219,7 @@ func writeMeSHCSV(path string, record *mesh.MeSHRecord) error {
 	}
 	defer f.Close()
 
-	w.Write([]string{"UI", "Name", "ScopeNote", "TreeNumbers", "EntryTerms", "Annotation"})
+	w.Write([]string{"UI", "Name", "ScopeNote", "TreeNumbers", "EntryTerms", "Annotation", "AllowableQualifiers"})
 	w.Write([]string{
 		record.UI,
 		record.Name,
@@ -142,12 +227,170 @@ func writeMeSHCSV(path string, record *mesh.MeSHRecord) error {
 		strings.Join(record.TreeNumbers, "; "),
 		strings.Join(record.EntryTerms, "; "),
 		record.Annotation,
+		strings.Join(qualifierLabels(record.AllowableQualifiers), "; "),
 	})
 
 	w.Flush()
 	return w.Error()
 }
 
+// writeMeSHCandidatesCSV exports MeSH search candidates in rank order.
+// Columns: UI,Name,Type,Match,TreeNumbers,EntryTerms
+func writeMeSHCandidatesCSV(path string, candidates []mesh.Candidate) error {
+	w, f, err := createCSV(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Write([]string{"UI", "Name", "Type", "Match", "TreeNumbers", "EntryTerms"})
+	for _, c := range candidates {
+		w.Write([]string{
+			c.UI,
+			c.Name,
+			string(c.Type),
+			string(c.Match),
+			strings.Join(c.TreeNumbers, "; "),
+			strings.Join(c.EntryTerms, "; "),
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// writeMeSHTreeCSV exports a MeSH hierarchy, one row per tree position.
+// Columns: Relation,TreeNumber,UI,Name,ParentTreeNumber,Level
+func writeMeSHTreeCSV(path string, tree *mesh.Tree, parents, siblings []mesh.TreeNode) error {
+	w, f, err := createCSV(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Write([]string{"Relation", "TreeNumber", "UI", "Name", "ParentTreeNumber", "Level"})
+	for _, n := range parents {
+		w.Write([]string{"parent", n.TreeNumber, n.UI, n.Name, "", ""})
+	}
+	for _, n := range siblings {
+		w.Write([]string{"sibling", n.TreeNumber, n.UI, n.Name, "", ""})
+	}
+	walkMeSHTree(tree, func(n mesh.TreeNode, parent string, level int) {
+		relation := "descendant"
+		if level == 0 {
+			relation = "self"
+		}
+		w.Write([]string{relation, n.TreeNumber, n.UI, n.Name, parent, strconv.Itoa(level)})
+	})
+
+	w.Flush()
+	return w.Error()
+}
+
+// writeFieldsCSV exports EInfo search fields to CSV.
+// Columns: Tag,Name,FullName,Description,TermCount,IsDate,IsNumerical,Hidden
+func writeFieldsCSV(path string, info *eutils.DBInfo) error {
+	w, f, err := createCSV(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Write([]string{"Tag", "Name", "FullName", "Description", "TermCount", "IsDate", "IsNumerical", "Hidden"})
+	for _, fi := range info.Fields {
+		w.Write([]string{
+			fi.Tag(),
+			fi.Name,
+			fi.FullName,
+			fi.Description,
+			strconv.Itoa(fi.TermCount),
+			strconv.FormatBool(fi.IsDate),
+			strconv.FormatBool(fi.IsNumerical),
+			strconv.FormatBool(fi.Hidden),
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// writeLinkNamesCSV exports EInfo link names to CSV.
+// Columns: Name,Menu,Description,DbTo
+func writeLinkNamesCSV(path string, info *eutils.DBInfo) error {
+	w, f, err := createCSV(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Write([]string{"Name", "Menu", "Description", "DbTo"})
+	for _, l := range info.Links {
+		w.Write([]string{l.Name, l.Menu, l.Description, l.DbTo})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// writeIDRecordsCSV exports ID conversion results to CSV.
+// Columns: Input,Type,PMID,PMCID,DOI,MID,Source,Error
+func writeIDRecordsCSV(path string, records []idconv.Record) error {
+	w, f, err := createCSV(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w.Write([]string{"Input", "Type", "PMID", "PMCID", "DOI", "MID", "Source", "Error"})
+	for _, r := range records {
+		w.Write([]string{r.Input, string(r.Type), r.PMID, r.PMCID, r.DOI, r.MID, r.Source, r.Error})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// grantLabels renders grants as "Agency GrantID" (ID omitted when absent).
+func grantLabels(grants []eutils.Grant) []string {
+	labels := make([]string, 0, len(grants))
+	for _, g := range grants {
+		label := g.Agency
+		if g.GrantID != "" {
+			label = strings.TrimSpace(label + " " + g.GrantID)
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// authorAffiliations renders "Name: aff1 | aff2" for each author with at
+// least one affiliation.
+func authorAffiliations(authors []eutils.Author) []string {
+	var out []string
+	for _, au := range authors {
+		affs := au.Affiliations
+		if len(affs) == 0 && au.Affiliation != "" {
+			affs = []string{au.Affiliation}
+		}
+		if len(affs) == 0 {
+			continue
+		}
+		out = append(out, au.FullName()+": "+strings.Join(affs, " | "))
+	}
+	return out
+}
+
+// authorORCIDs renders "Name: ORCID" for each author with an ORCID.
+func authorORCIDs(authors []eutils.Author) []string {
+	var out []string
+	for _, au := range authors {
+		if au.ORCID != "" {
+			out = append(out, au.FullName()+": "+au.ORCID)
+		}
+	}
+	return out
+}
+
 func createCSV(path string) (*csv.Writer, *os.File, error) {
 	f, err := os.Create(path)
 	if err != nil {
@@ -27,6 +27,7 @@ func TestWriteSearchCSV_WithArticles(t *testing.T) {
 			Journal:          "J One",
 			DOI:              "10.1/a",
 			PublicationTypes: []string{"Review"},
+			PubDate:          eutils.PubDate{Year: 2024, Month: 3, Day: 5},
 		},
 		{
 			PMID:             "222",
@@ -50,7 +51,7 @@ func TestWriteSearchCSV_WithArticles(t *testing.T) {
 	}
 
 	// Header
-	expectHeader := []string{"PMID", "Title", "Year", "Journal", "DOI", "Type"}
+	expectHeader := []string{"PMID", "Title", "Year", "Journal", "DOI", "Type", "PubDate"}
 	for i, h := range expectHeader {
 		if rows[0][i] != h {
 			t.Errorf("header[%d]: expected %q, got %q", i, h, rows[0][i])
@@ -67,6 +68,9 @@ func TestWriteSearchCSV_WithArticles(t *testing.T) {
 	if rows[1][2] != "2024" {
 		t.Errorf("row 1 Year: expected '2024', got %q", rows[1][2])
 	}
+	if rows[1][6] != "2024-03-05" {
+		t.Errorf("row 1 PubDate: expected '2024-03-05', got %q", rows[1][6])
+	}
 
 	// Row 2 — multi-value type should be joined
 	if rows[2][5] != "Journal Article; Meta-Analysis" {
@@ -155,6 +159,50 @@ func TestWriteArticlesCSV(t *testing.T) {
 	}
 }
 
+func TestWriteArticlesCSV_FundingAndAffiliations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "articles.csv")
+
+	articles := []eutils.Article{
+		{
+			PMID: "37000111",
+			Authors: []eutils.Author{
+				{LastName: "Pedapati", ForeName: "Ernest V", ORCID: "0000-0002-1825-0097", Affiliations: []string{"CCHMC", "University of Cincinnati"}},
+				{LastName: "Erickson", ForeName: "Craig A"},
+			},
+			Keywords:     []string{"EEG", "FMR1"},
+			Grants:       []eutils.Grant{{GrantID: "R01 HD093771", Agency: "NICHD NIH HHS"}},
+			COIStatement: "None declared.",
+		},
+	}
+	if err := writeArticlesCSV(path, articles); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows := readCSV(t, path)
+	col := make(map[string]int, len(rows[0]))
+	for i, h := range rows[0] {
+		col[h] = i
+	}
+	row := rows[1]
+
+	checks := map[string]string{
+		"Keywords":           "EEG; FMR1",
+		"Grants":             "NICHD NIH HHS R01 HD093771",
+		"AuthorAffiliations": "Ernest V Pedapati: CCHMC | University of Cincinnati",
+		"ORCIDs":             "Ernest V Pedapati: 0000-0002-1825-0097",
+		"COI":                "None declared.",
+	}
+	for name, want := range checks {
+		i, ok := col[name]
+		if !ok {
+			t.Fatalf("missing column %q in header %v", name, rows[0])
+		}
+		if row[i] != want {
+			t.Errorf("%s: expected %q, got %q", name, want, row[i])
+		}
+	}
+}
+
 func TestWriteLinksCSV(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "links.csv")
@@ -198,6 +246,10 @@ func TestWriteMeSHCSV(t *testing.T) {
 		ScopeNote:   "A condition...",
 		TreeNumbers: []string{"C10.597", "C16.320"},
 		EntryTerms:  []string{"FXS", "Martin-Bell"},
+		AllowableQualifiers: []mesh.Qualifier{
+			{UI: "Q000235", Name: "genetics", Abbreviation: "GE"},
+			{Name: "therapy", Abbreviation: "TH"},
+		},
 	}
 
 	err := writeMeSHCSV(path, record)
@@ -219,6 +271,9 @@ func TestWriteMeSHCSV(t *testing.T) {
 	if !strings.Contains(rows[1][3], "C10.597") {
 		t.Errorf("TreeNumbers should contain 'C10.597', got %q", rows[1][3])
 	}
+	if rows[1][6] != "genetics (GE); therapy (TH)" {
+		t.Errorf("AllowableQualifiers: expected 'genetics (GE); therapy (TH)', got %q", rows[1][6])
+	}
 }
 
 // readCSV is a test helper that reads and parses a CSV file.
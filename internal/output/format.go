@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/idconv"
 	"github.com/henrybloomingdale/pubmed-cli/internal/mesh"
 )
 
@@ -37,6 +38,32 @@ func FormatSearchResult(w io.Writer, result *eutils.SearchResult, articles []eut
 	return formatSearchPlain(w, result)
 }
 
+// ExpandedSearchOutput is the JSON form of a search run with MeSH query
+// expansion: the search result plus how its query was built.
+type ExpandedSearchOutput struct {
+	*eutils.SearchResult
+	Expansion *mesh.Expansion `json:"expansion"`
+}
+
+// FormatExpandedSearch writes a search result preceded by the MeSH
+// expansion of its query and each mapping decision.
+func FormatExpandedSearch(w io.Writer, exp *mesh.Expansion, result *eutils.SearchResult, articles []eutils.Article, cfg OutputConfig) error {
+	if cfg.CSVFile != "" {
+		if err := writeSearchCSV(cfg.CSVFile, result, articles); err != nil {
+			return fmt.Errorf("CSV export failed: %w", err)
+		}
+	}
+	if cfg.JSON {
+		return writeJSON(w, ExpandedSearchOutput{SearchResult: result, Expansion: exp})
+	}
+	if cfg.Human {
+		formatExpansionHuman(w, exp)
+		return formatSearchHuman(w, result, articles)
+	}
+	formatExpansionPlain(w, exp)
+	return formatSearchPlain(w, result)
+}
+
 // FormatArticles writes article details.
 func FormatArticles(w io.Writer, articles []eutils.Article, cfg OutputConfig) error {
 	if cfg.CSVFile != "" {
@@ -74,6 +101,90 @@ func FormatLinks(w io.Writer, result *eutils.LinkResult, linkType string, cfg Ou
 	return formatLinksPlain(w, result, linkType)
 }
 
+// FormatLinkResults writes link results for several source PMIDs. JSON
+// output maps each source PMID to its links.
+func FormatLinkResults(w io.Writer, results []eutils.LinkResult, linkType string, cfg OutputConfig) error {
+	if cfg.CSVFile != "" {
+		if err := writeLinkResultsCSV(cfg.CSVFile, results); err != nil {
+			return fmt.Errorf("CSV export failed: %w", err)
+		}
+	}
+	if cfg.JSON {
+		bySource := make(map[string][]eutils.LinkItem, len(results))
+		for _, r := range results {
+			bySource[r.SourceID] = r.Links
+		}
+		return writeJSON(w, bySource)
+	}
+	if cfg.Human {
+		return formatLinkResultsHuman(w, results, linkType)
+	}
+	for i := range results {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if err := formatLinksPlain(w, &results[i], linkType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AggregateOutput is the JSON form of aggregated links.
+type AggregateOutput struct {
+	SourceIDs []string                `json:"source_ids"`
+	Links     []eutils.AggregatedLink `json:"links"`
+}
+
+// FormatAggregatedLinks writes the union of several PMIDs' links with the
+// number of sources linking to each. articleMap supplies titles for the
+// first limit rows of human output and may be nil.
+func FormatAggregatedLinks(w io.Writer, sourceIDs []string, links []eutils.AggregatedLink, linkType string, articleMap map[string]eutils.Article, limit int, cfg OutputConfig) error {
+	if cfg.CSVFile != "" {
+		if err := writeAggregatedLinksCSV(cfg.CSVFile, links); err != nil {
+			return fmt.Errorf("CSV export failed: %w", err)
+		}
+	}
+	if cfg.JSON {
+		return writeJSON(w, AggregateOutput{SourceIDs: sourceIDs, Links: links})
+	}
+	if cfg.Human {
+		return formatAggregatedLinksHuman(w, sourceIDs, links, linkType, articleMap, limit)
+	}
+	return formatAggregatedLinksPlain(w, sourceIDs, links, linkType)
+}
+
+// LinkSetOutput is the JSON form of cross-database links: the link sets
+// plus summaries of the linked records.
+type LinkSetOutput struct {
+	LinkSets []eutils.LinkSet       `json:"linksets"`
+	Records  []eutils.RecordSummary `json:"records"`
+}
+
+// FormatLinkSets writes cross-database ELink results. records label linked
+// IDs where available; IDs without a summary are listed bare.
+func FormatLinkSets(w io.Writer, sets []eutils.LinkSet, records []eutils.RecordSummary, cfg OutputConfig) error {
+	byID := make(map[string]eutils.RecordSummary, len(records))
+	for _, r := range records {
+		byID[r.UID] = r
+	}
+	if cfg.CSVFile != "" {
+		if err := writeLinkSetsCSV(cfg.CSVFile, sets, byID); err != nil {
+			return fmt.Errorf("CSV export failed: %w", err)
+		}
+	}
+	if cfg.JSON {
+		if records == nil {
+			records = []eutils.RecordSummary{}
+		}
+		return writeJSON(w, LinkSetOutput{LinkSets: sets, Records: records})
+	}
+	if cfg.Human {
+		return formatLinkSetsHuman(w, sets, byID)
+	}
+	return formatLinkSetsPlain(w, sets, byID)
+}
+
 // FormatMeSHRecord writes a MeSH record.
 func FormatMeSHRecord(w io.Writer, record *mesh.MeSHRecord, cfg OutputConfig) error {
 	if cfg.CSVFile != "" {
@@ -90,11 +201,117 @@ func FormatMeSHRecord(w io.Writer, record *mesh.MeSHRecord, cfg OutputConfig) er
 	return formatMeSHPlain(w, record)
 }
 
+// FormatMeSHCandidates writes ranked MeSH search candidates.
+func FormatMeSHCandidates(w io.Writer, candidates []mesh.Candidate, cfg OutputConfig) error {
+	if cfg.CSVFile != "" {
+		if err := writeMeSHCandidatesCSV(cfg.CSVFile, candidates); err != nil {
+			return fmt.Errorf("CSV export failed: %w", err)
+		}
+	}
+	if cfg.JSON {
+		return writeJSON(w, candidates)
+	}
+	if cfg.Human {
+		return formatMeSHCandidatesHuman(w, candidates)
+	}
+	return formatMeSHCandidatesPlain(w, candidates)
+}
+
+// MeSHTreeOutput is the JSON form of a MeSH hierarchy: the descendants as
+// an adjacency list plus the descriptor's parents and siblings.
+type MeSHTreeOutput struct {
+	*mesh.Tree
+	Parents  []mesh.TreeNode `json:"parents,omitempty"`
+	Siblings []mesh.TreeNode `json:"siblings,omitempty"`
+}
+
+// FormatMeSHTree writes the hierarchy around a MeSH descriptor.
+func FormatMeSHTree(w io.Writer, tree *mesh.Tree, parents, siblings []mesh.TreeNode, cfg OutputConfig) error {
+	if cfg.CSVFile != "" {
+		if err := writeMeSHTreeCSV(cfg.CSVFile, tree, parents, siblings); err != nil {
+			return fmt.Errorf("CSV export failed: %w", err)
+		}
+	}
+	if cfg.JSON {
+		return writeJSON(w, MeSHTreeOutput{Tree: tree, Parents: parents, Siblings: siblings})
+	}
+	if cfg.Human {
+		return formatMeSHTreeHuman(w, tree, parents, siblings)
+	}
+	return formatMeSHTreePlain(w, tree, parents, siblings)
+}
+
+// walkMeSHTree calls fn for every node below and including the roots,
+// depth first, with its parent tree number and its level (0 for roots).
+func walkMeSHTree(tree *mesh.Tree, fn func(node mesh.TreeNode, parent string, level int)) {
+	var walk func(tn, parent string, level int)
+	walk = func(tn, parent string, level int) {
+		fn(tree.Nodes[tn], parent, level)
+		for _, child := range tree.Children[tn] {
+			walk(child, tn, level+1)
+		}
+	}
+	for _, root := range tree.Roots {
+		walk(root, "", 0)
+	}
+}
+
+// FormatDBFields writes the searchable fields of an EInfo database record.
+func FormatDBFields(w io.Writer, info *eutils.DBInfo, cfg OutputConfig) error {
+	if cfg.CSVFile != "" {
+		if err := writeFieldsCSV(cfg.CSVFile, info); err != nil {
+			return fmt.Errorf("CSV export failed: %w", err)
+		}
+	}
+	if cfg.JSON {
+		return writeJSON(w, info.Fields)
+	}
+	if cfg.Human {
+		return formatFieldsHuman(w, info)
+	}
+	return formatFieldsPlain(w, info)
+}
+
+// FormatDBLinks writes the ELink link names of an EInfo database record.
+func FormatDBLinks(w io.Writer, info *eutils.DBInfo, cfg OutputConfig) error {
+	if cfg.CSVFile != "" {
+		if err := writeLinkNamesCSV(cfg.CSVFile, info); err != nil {
+			return fmt.Errorf("CSV export failed: %w", err)
+		}
+	}
+	if cfg.JSON {
+		return writeJSON(w, info.Links)
+	}
+	if cfg.Human {
+		return formatLinkNamesHuman(w, info)
+	}
+	return formatLinkNamesPlain(w, info)
+}
+
+// FormatIDRecords writes ID conversion results.
+func FormatIDRecords(w io.Writer, records []idconv.Record, cfg OutputConfig) error {
+	if cfg.CSVFile != "" {
+		if err := writeIDRecordsCSV(cfg.CSVFile, records); err != nil {
+			return fmt.Errorf("CSV export failed: %w", err)
+		}
+	}
+	if cfg.JSON {
+		return writeJSON(w, records)
+	}
+	if cfg.Human {
+		return formatIDRecordsHuman(w, records)
+	}
+	return formatIDRecordsPlain(w, records)
+}
+
 // --- Plain text formatters (default) ---
 
 func formatSearchPlain(w io.Writer, result *eutils.SearchResult) error {
 	if result.Count == 0 {
 		fmt.Fprintln(w, "No results found.")
+		if result.SuggestedQuery != "" {
+			fmt.Fprintf(w, "Did you mean: %s\n", result.SuggestedQuery)
+		}
 		return nil
 	}
 
@@ -104,18 +321,36 @@ func formatSearchPlain(w io.Writer, result *eutils.SearchResult) error {
 	}
 	fmt.Fprintln(w)
 
+	if result.SuggestedQuery != "" {
+		fmt.Fprintf(w, "Showing results for: %s\n", result.SuggestedQuery)
+	}
 	if result.QueryTranslation != "" {
 		fmt.Fprintf(w, "Query: %s\n", result.QueryTranslation)
 	}
 	fmt.Fprintln(w)
 
 	for i, id := range result.IDs {
-		fmt.Fprintf(w, "  %d. PMID: %s\n", i+1, id)
+		fmt.Fprintf(w, "  %d. PMID: %s\n", result.Offset+i+1, id)
 	}
 
 	return nil
 }
 
+func formatExpansionPlain(w io.Writer, exp *mesh.Expansion) {
+	fmt.Fprintln(w, "MeSH expansion:")
+	if len(exp.Mappings) == 0 {
+		fmt.Fprintln(w, "  no free-text terms")
+	}
+	for _, m := range exp.Mappings {
+		if m.Mapped() {
+			fmt.Fprintf(w, "  %s -> %s (%s)\n", m.Term, m.Descriptor, m.UI)
+		} else {
+			fmt.Fprintf(w, "  %s: kept as free text (%s)\n", m.Term, m.Reason)
+		}
+	}
+	fmt.Fprintf(w, "Expanded query: %s\n\n", exp.Query)
+}
+
 func formatArticlesPlain(w io.Writer, articles []eutils.Article) error {
 	if len(articles) == 0 {
 		fmt.Fprintln(w, "No articles found.")
@@ -151,7 +386,11 @@ func formatArticlesPlain(w io.Writer, articles []eutils.Article) error {
 		if a.Year != "" {
 			citation += " (" + a.Year + ")"
 		}
-		fmt.Fprintf(w, "Journal: %s\n", citation)
+		if a.Book != nil {
+			fmt.Fprintf(w, "Book: %s\n", bookCitation(a))
+		} else {
+			fmt.Fprintf(w, "Journal: %s\n", citation)
+		}
 
 		if a.DOI != "" {
 			fmt.Fprintf(w, "DOI: %s\n", a.DOI)
@@ -162,6 +401,12 @@ func formatArticlesPlain(w io.Writer, articles []eutils.Article) error {
 		if len(a.PublicationTypes) > 0 {
 			fmt.Fprintf(w, "Type: %s\n", strings.Join(a.PublicationTypes, ", "))
 		}
+		if a.Retracted {
+			fmt.Fprintln(w, "Status: RETRACTED")
+		}
+		for _, c := range a.Corrections {
+			fmt.Fprintf(w, "Notice: %s\n", correctionNotice(c))
+		}
 		if a.Abstract != "" {
 			fmt.Fprintln(w)
 			fmt.Fprintln(w, "Abstract:")
@@ -172,12 +417,12 @@ func formatArticlesPlain(w io.Writer, articles []eutils.Article) error {
 			fmt.Fprintln(w, "MeSH Terms:")
 			for _, m := range a.MeSHTerms {
 				marker := "  "
-				if m.MajorTopic {
+				if m.Major() {
 					marker = "* "
 				}
 				term := m.Descriptor
 				if len(m.Qualifiers) > 0 {
-					term += " / " + strings.Join(m.Qualifiers, ", ")
+					term += " / " + strings.Join(m.QualifierNames(), ", ")
 				}
 				fmt.Fprintf(w, "  %s%s\n", marker, term)
 			}
@@ -187,21 +432,47 @@ func formatArticlesPlain(w io.Writer, articles []eutils.Article) error {
 	return nil
 }
 
+// bookCitation formats a Bookshelf record's source, e.g.
+// "GeneReviews. Seattle (WA): University of Washington, Seattle; 1993".
+func bookCitation(a eutils.Article) string {
+	b := a.Book
+	citation := b.Title
+	if b.Edition != "" {
+		citation += ". " + b.Edition
+	}
+	publisher := b.Publisher
+	if b.PublisherLocation != "" && publisher != "" {
+		publisher = b.PublisherLocation + ": " + publisher
+	}
+	if publisher != "" {
+		citation += ". " + publisher
+	}
+	if a.Year != "" {
+		citation += "; " + a.Year
+	}
+	return citation
+}
+
+// correctionNotice describes a correction notice, e.g.
+// "Retraction: Transl Psychiatry. 2021;11(1):150 (PMID 33622222)".
+func correctionNotice(c eutils.Correction) string {
+	notice := c.Type.Label()
+	if c.Source != "" {
+		notice += ": " + c.Source
+	}
+	if c.PMID != "" {
+		notice += " (PMID " + c.PMID + ")"
+	}
+	return notice
+}
+
 func formatLinksPlain(w io.Writer, result *eutils.LinkResult, linkType string) error {
 	if len(result.Links) == 0 {
 		fmt.Fprintf(w, "No %s results for PMID %s.\n", linkType, result.SourceID)
 		return nil
 	}
 
-	title := linkType
-	switch linkType {
-	case "cited-by":
-		title = "Cited By"
-	case "references":
-		title = "References"
-	case "related":
-		title = "Related Articles"
-	}
+	_, title := linkHeading(linkType)
 
 	fmt.Fprintf(w, "%s for PMID %s (%d results):\n\n", title, result.SourceID, len(result.Links))
 
@@ -216,6 +487,96 @@ func formatLinksPlain(w io.Writer, result *eutils.LinkResult, linkType string) e
 	return nil
 }
 
+func formatAggregatedLinksPlain(w io.Writer, sourceIDs []string, links []eutils.AggregatedLink, linkType string) error {
+	if len(links) == 0 {
+		fmt.Fprintf(w, "No %s results for %d PMIDs.\n", linkType, len(sourceIDs))
+		return nil
+	}
+
+	_, title := linkHeading(linkType)
+	fmt.Fprintf(w, "%s across %d PMIDs (%d unique):\n\n", title, len(sourceIDs), len(links))
+	for i, link := range links {
+		fmt.Fprintf(w, "  %d. PMID: %s (%d/%d: %s)\n", i+1, link.ID, link.Count, len(sourceIDs), strings.Join(link.Sources, ", "))
+	}
+	return nil
+}
+
+func formatLinkSetsPlain(w io.Writer, sets []eutils.LinkSet, records map[string]eutils.RecordSummary) error {
+	for i, set := range sets {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if len(set.LinkSetDBs) == 0 {
+			fmt.Fprintf(w, "No links for %s.\n", linkSourceLabel(set))
+			continue
+		}
+		fmt.Fprintf(w, "Links for %s:\n", linkSourceLabel(set))
+		for _, db := range set.LinkSetDBs {
+			fmt.Fprintf(w, "\n%s → %s (%d):\n", db.LinkName, db.DbTo, len(db.Links))
+			for _, l := range db.Links {
+				r, ok := records[l.ID]
+				if !ok {
+					fmt.Fprintf(w, "  %s\n", l.ID)
+					continue
+				}
+				line := fmt.Sprintf("  %s\t%s\t%s", l.ID, dash(r.Accession), r.Title)
+				if r.Organism != "" {
+					line += " [" + r.Organism + "]"
+				}
+				fmt.Fprintln(w, line)
+			}
+		}
+	}
+	return nil
+}
+
+// linkSourceLabel names a link set's source record, e.g. "PMID 123".
+func linkSourceLabel(set eutils.LinkSet) string {
+	if set.DbFrom == "" || set.DbFrom == "pubmed" {
+		return "PMID " + set.SourceID
+	}
+	return set.DbFrom + " " + set.SourceID
+}
+
+func formatFieldsPlain(w io.Writer, info *eutils.DBInfo) error {
+	fmt.Fprintf(w, "Search fields for %s:\n\n", info.Name)
+	for _, f := range info.Fields {
+		if f.Hidden {
+			continue
+		}
+		fmt.Fprintf(w, "  %-8s %-28s %12d terms  %s\n", f.Tag(), f.FullName, f.TermCount, f.Description)
+	}
+	return nil
+}
+
+func formatLinkNamesPlain(w io.Writer, info *eutils.DBInfo) error {
+	fmt.Fprintf(w, "Link names from %s:\n\n", info.Name)
+	for _, l := range info.Links {
+		fmt.Fprintf(w, "  %-36s → %-12s %s\n", l.Name, l.DbTo, l.Description)
+	}
+	return nil
+}
+
+// formatIDRecordsPlain writes one tab-separated line per record:
+// input, PMID, PMCID, DOI, MID, with "-" for missing IDs and any error last.
+func formatIDRecordsPlain(w io.Writer, records []idconv.Record) error {
+	for _, r := range records {
+		fields := []string{r.Input, dash(r.PMID), dash(r.PMCID), dash(r.DOI), dash(r.MID)}
+		if r.Error != "" {
+			fields = append(fields, "error: "+r.Error)
+		}
+		fmt.Fprintln(w, strings.Join(fields, "\t"))
+	}
+	return nil
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
 func formatMeSHPlain(w io.Writer, record *mesh.MeSHRecord) error {
 	fmt.Fprintf(w, "MeSH Term: %s\n", record.Name)
 	fmt.Fprintf(w, "UI: %s\n", record.UI)
@@ -242,6 +603,11 @@ func formatMeSHPlain(w io.Writer, record *mesh.MeSHRecord) error {
 		}
 	}
 
+	if len(record.AllowableQualifiers) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Allowable Qualifiers: %s\n", strings.Join(qualifierLabels(record.AllowableQualifiers), ", "))
+	}
+
 	if record.Annotation != "" {
 		fmt.Fprintln(w)
 		fmt.Fprintf(w, "Annotation: %s\n", record.Annotation)
@@ -250,6 +616,51 @@ func formatMeSHPlain(w io.Writer, record *mesh.MeSHRecord) error {
 	return nil
 }
 
+// qualifierLabels renders qualifiers as "genetics (GE)", or just the name
+// or abbreviation when only one is known.
+func qualifierLabels(qualifiers []mesh.Qualifier) []string {
+	labels := make([]string, len(qualifiers))
+	for i, q := range qualifiers {
+		labels[i] = q.String()
+		if q.Name != "" && q.Abbreviation != "" {
+			labels[i] += " (" + q.Abbreviation + ")"
+		}
+	}
+	return labels
+}
+
+func formatMeSHCandidatesPlain(w io.Writer, candidates []mesh.Candidate) error {
+	fmt.Fprintf(w, "MeSH candidates: %d\n\n", len(candidates))
+	for i, c := range candidates {
+		fmt.Fprintf(w, "  %d. %s (%s) %s, %s match\n", i+1, c.Name, c.UI, c.Type, strings.ReplaceAll(string(c.Match), "_", " "))
+	}
+	return nil
+}
+
+func formatMeSHTreePlain(w io.Writer, tree *mesh.Tree, parents, siblings []mesh.TreeNode) error {
+	fmt.Fprintf(w, "MeSH Tree: %s (%s)\n", tree.Name, tree.UI)
+
+	for _, group := range []struct {
+		label string
+		nodes []mesh.TreeNode
+	}{{"Parents", parents}, {"Siblings", siblings}} {
+		if len(group.nodes) == 0 {
+			continue
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%s:\n", group.label)
+		for _, n := range group.nodes {
+			fmt.Fprintf(w, "  %s  %s (%s)\n", n.TreeNumber, n.Name, n.UI)
+		}
+	}
+
+	fmt.Fprintln(w)
+	walkMeSHTree(tree, func(n mesh.TreeNode, _ string, level int) {
+		fmt.Fprintf(w, "%s%s  %s (%s)\n", strings.Repeat("  ", level), n.TreeNumber, n.Name, n.UI)
+	})
+	return nil
+}
+
 func writeJSON(w io.Writer, v interface{}) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/idconv"
+	"github.com/henrybloomingdale/pubmed-cli/internal/mesh"
 )
 
 func TestFormatSearchJSON(t *testing.T) {
@@ -64,6 +66,24 @@ func TestFormatSearchPlain(t *testing.T) {
 	}
 }
 
+func TestFormatSearchPlain_Offset(t *testing.T) {
+	result := &eutils.SearchResult{
+		Count:  42,
+		Offset: 20,
+		IDs:    []string{"123", "456"},
+	}
+
+	var buf bytes.Buffer
+	if err := FormatSearchResult(&buf, result, nil, OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "21. PMID: 123") || !strings.Contains(out, "22. PMID: 456") {
+		t.Errorf("expected ranks to continue from offset, got:\n%s", out)
+	}
+}
+
 func TestFormatSearchEmpty(t *testing.T) {
 	result := &eutils.SearchResult{
 		Count: 0,
@@ -82,6 +102,34 @@ func TestFormatSearchEmpty(t *testing.T) {
 	}
 }
 
+func TestFormatSearchPlain_SuggestedQuery(t *testing.T) {
+	var buf bytes.Buffer
+	empty := &eutils.SearchResult{Count: 0, SuggestedQuery: "asthma AND children"}
+	if err := FormatSearchResult(&buf, empty, nil, OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Did you mean: asthma AND children") {
+		t.Errorf("expected suggestion for zero hits, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	corrected := &eutils.SearchResult{Count: 1, IDs: []string{"123"}, SuggestedQuery: "asthma AND children"}
+	if err := FormatSearchResult(&buf, corrected, nil, OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Showing results for: asthma AND children") {
+		t.Errorf("expected auto-corrected notice, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := FormatSearchResult(&buf, empty, nil, OutputConfig{JSON: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"suggested_query": "asthma AND children"`) {
+		t.Errorf("expected suggested_query in JSON, got:\n%s", buf.String())
+	}
+}
+
 func TestFormatArticleJSON(t *testing.T) {
 	articles := []eutils.Article{
 		{
@@ -197,6 +245,35 @@ func TestFormatArticleEmptyYear(t *testing.T) {
 	}
 }
 
+func TestFormatArticlePlain_BookChapter(t *testing.T) {
+	articles := []eutils.Article{
+		{
+			PMID:  "20301558",
+			Title: "FMR1 Disorders",
+			Year:  "1993",
+			Book: &eutils.BookInfo{
+				Type:              eutils.BookTypeChapter,
+				Title:             "GeneReviews",
+				Publisher:         "University of Washington, Seattle",
+				PublisherLocation: "Seattle (WA)",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := FormatArticles(&buf, articles, OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Book: GeneReviews. Seattle (WA): University of Washington, Seattle; 1993") {
+		t.Errorf("expected book citation, got:\n%s", out)
+	}
+	if strings.Contains(out, "Journal:") {
+		t.Errorf("book records should not print a Journal line, got:\n%s", out)
+	}
+}
+
 func TestFormatArticleEmpty(t *testing.T) {
 	var buf bytes.Buffer
 	err := FormatArticles(&buf, []eutils.Article{}, OutputConfig{})
@@ -311,3 +388,350 @@ func TestFormatLinksEmpty(t *testing.T) {
 		t.Errorf("expected 'no results' message, got %q", out)
 	}
 }
+
+func testDBInfo() *eutils.DBInfo {
+	return &eutils.DBInfo{
+		Name: "pubmed",
+		Fields: []eutils.FieldInfo{
+			{Name: "TIAB", FullName: "Title/Abstract", Description: "Free text associated with Abstract and Title", TermCount: 1234567},
+			{Name: "UID", FullName: "UID", Hidden: true},
+		},
+		Links: []eutils.LinkInfo{
+			{Name: "pubmed_gene", Menu: "Gene Links", Description: "Gene records", DbTo: "gene"},
+		},
+	}
+}
+
+func TestFormatDBFieldsPlain(t *testing.T) {
+	var buf bytes.Buffer
+	if err := FormatDBFields(&buf, testDBInfo(), OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "[tiab]") || !strings.Contains(out, "Title/Abstract") || !strings.Contains(out, "1234567 terms") {
+		t.Errorf("expected TIAB row, got:\n%s", out)
+	}
+	if strings.Contains(out, "[uid]") {
+		t.Errorf("expected hidden fields to be omitted, got:\n%s", out)
+	}
+}
+
+func TestFormatDBLinksJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := FormatDBLinks(&buf, testDBInfo(), OutputConfig{JSON: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var links []eutils.LinkInfo
+	if err := json.Unmarshal(buf.Bytes(), &links); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(links) != 1 || links[0].Name != "pubmed_gene" || links[0].DbTo != "gene" {
+		t.Errorf("unexpected links: %+v", links)
+	}
+}
+
+func TestFormatIDRecords(t *testing.T) {
+	records := []idconv.Record{
+		{Input: "PMC123", Type: idconv.TypePMCID, PMID: "456", PMCID: "PMC123", DOI: "10.1/x", Source: idconv.SourceIDConv},
+		{Input: "bogus", Error: "unrecognized identifier"},
+	}
+	csvPath := filepath.Join(t.TempDir(), "ids.csv")
+
+	var buf bytes.Buffer
+	if err := FormatIDRecords(&buf, records, OutputConfig{CSVFile: csvPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "PMC123\t456\tPMC123\t10.1/x\t-\nbogus\t-\t-\t-\t-\terror: unrecognized identifier\n"
+	if buf.String() != want {
+		t.Errorf("expected:\n%q\ngot:\n%q", want, buf.String())
+	}
+
+	rows := readCSV(t, csvPath)
+	if len(rows) != 3 || rows[1][1] != "pmcid" || rows[1][6] != "idconv" || rows[2][7] != "unrecognized identifier" {
+		t.Errorf("unexpected CSV rows: %v", rows)
+	}
+
+	buf.Reset()
+	if err := FormatIDRecords(&buf, records, OutputConfig{JSON: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []idconv.Record
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got) != 2 || got[0] != records[0] || got[1].Error == "" {
+		t.Errorf("unexpected decoded records: %+v", got)
+	}
+}
+
+func testLinkSets() ([]eutils.LinkSet, []eutils.RecordSummary) {
+	sets := []eutils.LinkSet{
+		{SourceID: "37286542", DbFrom: "pubmed", LinkSetDBs: []eutils.LinkSetDB{
+			{DbTo: "gene", LinkName: "pubmed_gene", Links: []eutils.LinkItem{{ID: "2332"}, {ID: "14265"}}},
+		}},
+		{SourceID: "38123456", DbFrom: "pubmed", LinkSetDBs: []eutils.LinkSetDB{}},
+	}
+	records := []eutils.RecordSummary{
+		{DB: "gene", UID: "2332", Accession: "FMR1", Title: "fragile X messenger ribonucleoprotein 1", Organism: "Homo sapiens"},
+	}
+	return sets, records
+}
+
+func TestFormatLinkSetsPlain(t *testing.T) {
+	sets, records := testLinkSets()
+	var buf bytes.Buffer
+	if err := FormatLinkSets(&buf, sets, records, OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Links for PMID 37286542:\n",
+		"\npubmed_gene → gene (2):\n",
+		"  2332\tFMR1\tfragile X messenger ribonucleoprotein 1 [Homo sapiens]\n",
+		"  14265\n",
+		"No links for PMID 38123456.\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatLinkSetsJSONAndCSV(t *testing.T) {
+	sets, records := testLinkSets()
+	csvPath := filepath.Join(t.TempDir(), "links.csv")
+
+	var buf bytes.Buffer
+	if err := FormatLinkSets(&buf, sets, records, OutputConfig{JSON: true, CSVFile: csvPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got LinkSetOutput
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got.LinkSets) != 2 || len(got.Records) != 1 || got.LinkSets[0].LinkSetDBs[0].LinkName != "pubmed_gene" {
+		t.Errorf("unexpected decoded output: %+v", got)
+	}
+
+	rows := readCSV(t, csvPath)
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %v", rows)
+	}
+	if rows[1][0] != "37286542" || rows[1][4] != "FMR1" || rows[2][3] != "14265" || rows[2][4] != "" {
+		t.Errorf("unexpected CSV rows: %v", rows)
+	}
+}
+
+func TestFormatLinkResults_MultipleSources(t *testing.T) {
+	results := []eutils.LinkResult{
+		{SourceID: "1", Links: []eutils.LinkItem{{ID: "10"}, {ID: "11", Score: 5}}},
+		{SourceID: "2", Links: []eutils.LinkItem{}},
+	}
+	csvPath := filepath.Join(t.TempDir(), "links.csv")
+
+	var buf bytes.Buffer
+	if err := FormatLinkResults(&buf, results, "cited-by", OutputConfig{JSON: true, CSVFile: csvPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got map[string][]eutils.LinkItem
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got["1"]) != 2 || got["2"] == nil || len(got["2"]) != 0 {
+		t.Errorf("unexpected source map: %+v", got)
+	}
+	rows := readCSV(t, csvPath)
+	if len(rows) != 3 || rows[2][0] != "1" || rows[2][1] != "11" || rows[2][2] != "5" {
+		t.Errorf("unexpected CSV rows: %v", rows)
+	}
+
+	buf.Reset()
+	if err := FormatLinkResults(&buf, results, "cited-by", OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Cited By for PMID 1 (2 results):") || !strings.Contains(out, "No cited-by results for PMID 2.") {
+		t.Errorf("expected a block per source, got:\n%s", out)
+	}
+}
+
+func TestFormatAggregatedLinks(t *testing.T) {
+	sources := []string{"1", "2"}
+	links := []eutils.AggregatedLink{
+		{ID: "10", Count: 2, Sources: []string{"1", "2"}},
+		{ID: "11", Count: 1, Sources: []string{"1"}},
+	}
+	csvPath := filepath.Join(t.TempDir(), "agg.csv")
+
+	var buf bytes.Buffer
+	if err := FormatAggregatedLinks(&buf, sources, links, "references", nil, 20, OutputConfig{CSVFile: csvPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"References across 2 PMIDs (2 unique):", "1. PMID: 10 (2/2: 1, 2)", "2. PMID: 11 (1/2: 1)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	rows := readCSV(t, csvPath)
+	if len(rows) != 3 || rows[1][1] != "2" || rows[1][2] != "1; 2" {
+		t.Errorf("unexpected CSV rows: %v", rows)
+	}
+
+	buf.Reset()
+	if err := FormatAggregatedLinks(&buf, sources, links, "references", nil, 20, OutputConfig{JSON: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got AggregateOutput
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got.SourceIDs) != 2 || len(got.Links) != 2 || got.Links[0].Count != 2 {
+		t.Errorf("unexpected decoded output: %+v", got)
+	}
+}
+
+func sampleMeSHTree() (*mesh.Tree, []mesh.TreeNode, []mesh.TreeNode) {
+	tree := &mesh.Tree{
+		UI:    "D008607",
+		Name:  "Intellectual Disability",
+		Depth: 1,
+		Roots: []string{"C10.597.606.360"},
+		Nodes: map[string]mesh.TreeNode{
+			"C10.597.606.360":     {TreeNumber: "C10.597.606.360", UI: "D008607", Name: "Intellectual Disability"},
+			"C10.597.606.360.220": {TreeNumber: "C10.597.606.360.220", UI: "D004314", Name: "Down Syndrome"},
+			"C10.597.606.360.320": {TreeNumber: "C10.597.606.360.320", UI: "D005600", Name: "Fragile X Syndrome"},
+		},
+		Children: map[string][]string{
+			"C10.597.606.360": {"C10.597.606.360.220", "C10.597.606.360.320"},
+		},
+	}
+	parents := []mesh.TreeNode{{TreeNumber: "C10.597.606", UI: "D019954", Name: "Neurodevelopmental Disorders"}}
+	siblings := []mesh.TreeNode{{TreeNumber: "C10.597.606.150", UI: "D001289", Name: "Attention Deficit Disorder with Hyperactivity"}}
+	return tree, parents, siblings
+}
+
+func TestFormatMeSHTree(t *testing.T) {
+	tree, parents, siblings := sampleMeSHTree()
+	csvPath := filepath.Join(t.TempDir(), "tree.csv")
+
+	var buf bytes.Buffer
+	if err := FormatMeSHTree(&buf, tree, parents, siblings, OutputConfig{CSVFile: csvPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"MeSH Tree: Intellectual Disability (D008607)",
+		"Parents:\n  C10.597.606  Neurodevelopmental Disorders (D019954)",
+		"Siblings:\n  C10.597.606.150",
+		"\nC10.597.606.360  Intellectual Disability (D008607)\n  C10.597.606.360.220  Down Syndrome (D004314)\n  C10.597.606.360.320",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	rows := readCSV(t, csvPath)
+	if len(rows) != 6 {
+		t.Fatalf("expected header + 5 rows, got %v", rows)
+	}
+	if rows[1][0] != "parent" || rows[2][0] != "sibling" || rows[3][0] != "self" {
+		t.Errorf("unexpected relations: %v", rows)
+	}
+	if got := rows[5]; got[0] != "descendant" || got[4] != "C10.597.606.360" || got[5] != "1" {
+		t.Errorf("unexpected descendant row: %v", got)
+	}
+
+	buf.Reset()
+	if err := FormatMeSHTree(&buf, tree, parents, nil, OutputConfig{JSON: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got struct {
+		UI       string              `json:"ui"`
+		Roots    []string            `json:"roots"`
+		Children map[string][]string `json:"children"`
+		Parents  []mesh.TreeNode     `json:"parents"`
+		Siblings []mesh.TreeNode     `json:"siblings"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.UI != "D008607" || len(got.Children["C10.597.606.360"]) != 2 || len(got.Parents) != 1 || got.Siblings != nil {
+		t.Errorf("unexpected decoded output: %+v", got)
+	}
+}
+
+func TestFormatExpandedSearch(t *testing.T) {
+	exp := &mesh.Expansion{
+		Original: "fxs AND sleep",
+		Query:    `("Fragile X Syndrome"[mh] OR "fxs"[tiab]) AND sleep`,
+		Mappings: []mesh.Mapping{
+			{Term: "fxs", Descriptor: "Fragile X Syndrome", UI: "D005600", Expansion: `("Fragile X Syndrome"[mh] OR "fxs"[tiab])`},
+			{Term: "sleep", Reason: "no MeSH descriptor"},
+		},
+	}
+	result := &eutils.SearchResult{Count: 1, IDs: []string{"37286542"}}
+
+	var buf bytes.Buffer
+	if err := FormatExpandedSearch(&buf, exp, result, nil, OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"fxs -> Fragile X Syndrome (D005600)",
+		"sleep: kept as free text (no MeSH descriptor)",
+		`Expanded query: ("Fragile X Syndrome"[mh] OR "fxs"[tiab]) AND sleep`,
+		"1. PMID: 37286542",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := FormatExpandedSearch(&buf, exp, result, nil, OutputConfig{JSON: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got ExpandedSearchOutput
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.SearchResult == nil || got.Count != 1 || got.Expansion.Query != exp.Query || len(got.Expansion.Mappings) != 2 {
+		t.Errorf("unexpected decoded output: %+v", got)
+	}
+}
+
+func TestFormatMeSHCandidates(t *testing.T) {
+	candidates := []mesh.Candidate{
+		{MeSHRecord: mesh.MeSHRecord{UI: "D005600", Name: "Fragile X Syndrome", TreeNumbers: []string{"C10.597.606.360.320"}}, Type: mesh.TypeDescriptor, Match: mesh.MatchName},
+		{MeSHRecord: mesh.MeSHRecord{UI: "C536400", Name: "Fragile X Tremor Ataxia Syndrome"}, Type: mesh.TypeSCR, Match: mesh.MatchPartial},
+	}
+	csvPath := filepath.Join(t.TempDir(), "candidates.csv")
+
+	var buf bytes.Buffer
+	if err := FormatMeSHCandidates(&buf, candidates, OutputConfig{CSVFile: csvPath}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"1. Fragile X Syndrome (D005600) descriptor, name match", "2. Fragile X Tremor Ataxia Syndrome (C536400) scr, partial match"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	rows := readCSV(t, csvPath)
+	if len(rows) != 3 || rows[2][2] != "scr" || rows[1][4] != "C10.597.606.360.320" {
+		t.Errorf("unexpected CSV rows: %v", rows)
+	}
+
+	buf.Reset()
+	if err := FormatMeSHCandidates(&buf, candidates, OutputConfig{JSON: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(got) != 2 || got[0]["ui"] != "D005600" || got[0]["type"] != "descriptor" || got[1]["match"] != "partial" {
+		t.Errorf("unexpected decoded output: %v", got)
+	}
+}
@@ -0,0 +1,288 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/pmc"
+)
+
+// Full-text formats accepted by FormatFullText.
+const (
+	FullTextPlain    = "text"
+	FullTextMarkdown = "markdown"
+	FullTextJSON     = "json"
+)
+
+// FormatFullText writes a PMC full-text article as plain text, Markdown or
+// JSON. --json selects JSON regardless of format, and --human renders the
+// plain layout with styled headings and wrapped paragraphs.
+func FormatFullText(w io.Writer, a *pmc.Article, format string, cfg OutputConfig) error {
+	format = strings.ToLower(format)
+	if cfg.JSON || format == FullTextJSON {
+		return writeJSON(w, a)
+	}
+	switch format {
+	case FullTextMarkdown, "md":
+		return formatFullTextMarkdown(w, a)
+	case "", FullTextPlain:
+		if cfg.Human {
+			return formatFullTextHuman(w, a)
+		}
+		return formatFullTextPlain(w, a)
+	}
+	return fmt.Errorf("unsupported full-text format %q (use text, markdown, or json)", format)
+}
+
+// fullTextIDs formats the identifier line shared by the text renderers.
+func fullTextIDs(a *pmc.Article) string {
+	ids := []string{"PMCID: " + a.PMCID}
+	if a.PMID != "" {
+		ids = append(ids, "PMID: "+a.PMID)
+	}
+	if a.DOI != "" {
+		ids = append(ids, "DOI: "+a.DOI)
+	}
+	return strings.Join(ids, " | ")
+}
+
+func fullTextSource(a *pmc.Article) string {
+	source := a.Journal
+	if a.Year != "" {
+		source = strings.TrimSpace(source + " (" + a.Year + ")")
+	}
+	return source
+}
+
+func formatFullTextPlain(w io.Writer, a *pmc.Article) error {
+	fmt.Fprintln(w, a.Title)
+	if len(a.Authors) > 0 {
+		fmt.Fprintln(w, strings.Join(a.Authors, ", "))
+	}
+	if source := fullTextSource(a); source != "" {
+		fmt.Fprintln(w, source)
+	}
+	fmt.Fprintln(w, fullTextIDs(a))
+	if a.License != "" {
+		fmt.Fprintf(w, "License: %s\n", a.License)
+	}
+
+	if a.Abstract != "" {
+		fmt.Fprintf(w, "\nABSTRACT\n\n%s\n", a.Abstract)
+	}
+	if !a.HasBody() {
+		fmt.Fprintln(w, "\n(Full text not available in XML from PMC.)")
+	}
+	var writeSection func(s pmc.Section, depth int)
+	writeSection = func(s pmc.Section, depth int) {
+		if s.Title != "" {
+			title := s.Title
+			if depth == 0 {
+				title = strings.ToUpper(title)
+			}
+			fmt.Fprintf(w, "\n%s\n", title)
+		}
+		for _, p := range s.Paragraphs {
+			fmt.Fprintf(w, "\n%s\n", p)
+		}
+		for _, sub := range s.Sections {
+			writeSection(sub, depth+1)
+		}
+	}
+	for _, s := range a.Sections {
+		writeSection(s, 0)
+	}
+
+	if len(a.Figures) > 0 {
+		fmt.Fprintln(w, "\nFIGURES")
+		for _, f := range a.Figures {
+			fmt.Fprintf(w, "\n%s\n", captionLine(f.Label, f.Caption))
+		}
+	}
+	if len(a.Tables) > 0 {
+		fmt.Fprintln(w, "\nTABLES")
+		for _, t := range a.Tables {
+			fmt.Fprintf(w, "\n%s\n", captionLine(t.Label, t.Caption))
+			for _, row := range t.Rows {
+				fmt.Fprintln(w, strings.Join(row, "\t"))
+			}
+		}
+	}
+	if len(a.References) > 0 {
+		fmt.Fprintf(w, "\nREFERENCES\n\n")
+		for i, r := range a.References {
+			fmt.Fprintf(w, "%d. %s\n", i+1, r.Citation)
+		}
+	}
+	return nil
+}
+
+func formatFullTextMarkdown(w io.Writer, a *pmc.Article) error {
+	fmt.Fprintf(w, "# %s\n\n", a.Title)
+	if len(a.Authors) > 0 {
+		fmt.Fprintf(w, "%s\n\n", strings.Join(a.Authors, ", "))
+	}
+	if source := fullTextSource(a); source != "" {
+		fmt.Fprintf(w, "*%s*  \n", source)
+	}
+	fmt.Fprintf(w, "%s\n", fullTextIDs(a))
+	if a.License != "" {
+		fmt.Fprintf(w, "\nLicense: %s\n", a.License)
+	}
+
+	if a.Abstract != "" {
+		fmt.Fprintf(w, "\n## Abstract\n\n%s\n", a.Abstract)
+	}
+	if !a.HasBody() {
+		fmt.Fprintln(w, "\n*Full text not available in XML from PMC.*")
+	}
+	var writeSection func(s pmc.Section, level int)
+	writeSection = func(s pmc.Section, level int) {
+		if s.Title != "" {
+			fmt.Fprintf(w, "\n%s %s\n", strings.Repeat("#", min(level, 6)), s.Title)
+		}
+		for _, p := range s.Paragraphs {
+			// List items are already one per line; make them Markdown bullets.
+			fmt.Fprintf(w, "\n%s\n", strings.ReplaceAll(p, "• ", "- "))
+		}
+		for _, sub := range s.Sections {
+			writeSection(sub, level+1)
+		}
+	}
+	for _, s := range a.Sections {
+		writeSection(s, 2)
+	}
+
+	if len(a.Figures) > 0 {
+		fmt.Fprintln(w, "\n## Figures")
+		for _, f := range a.Figures {
+			fmt.Fprintf(w, "\n%s\n", markdownCaption(f.Label, f.Caption))
+		}
+	}
+	if len(a.Tables) > 0 {
+		fmt.Fprintln(w, "\n## Tables")
+		for _, t := range a.Tables {
+			fmt.Fprintf(w, "\n%s\n", markdownCaption(t.Label, t.Caption))
+			writeMarkdownTable(w, t.Rows)
+		}
+	}
+	if len(a.References) > 0 {
+		fmt.Fprintf(w, "\n## References\n\n")
+		for i, r := range a.References {
+			fmt.Fprintf(w, "%d. %s\n", i+1, r.Citation)
+		}
+	}
+	return nil
+}
+
+func markdownCaption(label, caption string) string {
+	if label == "" {
+		return caption
+	}
+	return strings.TrimSpace("**" + label + "** " + caption)
+}
+
+// writeMarkdownTable renders rows as a pipe table, using the first row as
+// the header and padding short rows.
+func writeMarkdownTable(w io.Writer, rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	writeRow := func(row []string) {
+		cells := make([]string, cols)
+		for i := range cells {
+			if i < len(row) {
+				cells[i] = strings.ReplaceAll(row[i], "|", `\|`)
+			}
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+	}
+	fmt.Fprintln(w)
+	writeRow(rows[0])
+	fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", cols))
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+}
+
+func formatFullTextHuman(w io.Writer, a *pmc.Article) error {
+	card := bold.Render(a.Title) + "\n" + cyan.Render(a.PMCID)
+	if source := fullTextSource(a); source != "" {
+		card += dim.Render(" · ") + source
+	}
+	fmt.Fprintln(w, boxStyle.Render(card))
+	fmt.Fprintln(w)
+	if len(a.Authors) > 0 {
+		fmt.Fprintf(w, "  %s %s\n", labelStyle.Render("Authors:"), strings.Join(a.Authors, ", "))
+	}
+	fmt.Fprintf(w, "  %s\n", dim.Render(fullTextIDs(a)))
+
+	if a.Abstract != "" {
+		fmt.Fprintf(w, "\n%s\n\n%s\n", labelStyle.Render("Abstract"), wrapParagraph(a.Abstract))
+	}
+	if !a.HasBody() {
+		fmt.Fprintf(w, "\n%s\n", yellow.Render("Full text not available in XML from PMC."))
+	}
+	var writeSection func(s pmc.Section, depth int)
+	writeSection = func(s pmc.Section, depth int) {
+		if s.Title != "" {
+			style := labelStyle
+			if depth > 0 {
+				style = bold
+			}
+			fmt.Fprintf(w, "\n%s\n", style.Render(s.Title))
+		}
+		for _, p := range s.Paragraphs {
+			fmt.Fprintf(w, "\n%s\n", wrapParagraph(p))
+		}
+		for _, sub := range s.Sections {
+			writeSection(sub, depth+1)
+		}
+	}
+	for _, s := range a.Sections {
+		writeSection(s, 0)
+	}
+
+	for _, f := range a.Figures {
+		fmt.Fprintf(w, "\n%s %s\n", magenta.Render(f.Label), wrapParagraph(f.Caption))
+	}
+	for _, t := range a.Tables {
+		fmt.Fprintf(w, "\n%s %s\n", magenta.Render(t.Label), wrapParagraph(t.Caption))
+		if len(t.Rows) > 0 {
+			fmt.Fprintln(w, infoTable(t.Rows[0], t.Rows[1:]).Render())
+		}
+	}
+	if len(a.References) > 0 {
+		fmt.Fprintf(w, "\n%s\n\n", labelStyle.Render(fmt.Sprintf("References (%d)", len(a.References))))
+		for i, r := range a.References {
+			fmt.Fprintf(w, "  %s %s\n", dim.Render(fmt.Sprintf("%d.", i+1)), r.Citation)
+		}
+	}
+	return nil
+}
+
+// wrapParagraph word-wraps each line of p separately so list items stay
+// on their own lines.
+func wrapParagraph(p string) string {
+	lines := strings.Split(p, "\n")
+	for i, line := range lines {
+		lines[i] = wordWrap(line, 80)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// captionLine joins a figure or table label and its caption.
+func captionLine(label, caption string) string {
+	if label == "" {
+		return caption
+	}
+	if caption == "" {
+		return label
+	}
+	return label + ": " + caption
+}
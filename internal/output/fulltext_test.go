@@ -0,0 +1,114 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/pmc"
+)
+
+var fullTextArticle = &pmc.Article{
+	PMCID:    "PMC123",
+	PMID:     "456",
+	Title:    "A Study",
+	Journal:  "J Test",
+	Year:     "2023",
+	Authors:  []string{"Jane Smith"},
+	Abstract: "Background: Short.",
+	Sections: []pmc.Section{
+		{Title: "Introduction", Paragraphs: []string{"First paragraph."}},
+		{Title: "Methods", Sections: []pmc.Section{
+			{Title: "Participants", Paragraphs: []string{"• One\n• Two"}},
+		}},
+	},
+	Figures: []pmc.Figure{{Label: "Fig. 1", Caption: "Flow."}},
+	Tables: []pmc.Table{{Label: "Table 1", Caption: "Counts", Rows: [][]string{
+		{"Group", "N"}, {"A|B", "2"},
+	}}},
+	References: []pmc.Reference{{Citation: "Bear MF. Theory. 2004."}},
+}
+
+func TestFormatFullText_Plain(t *testing.T) {
+	var buf bytes.Buffer
+	if err := FormatFullText(&buf, fullTextArticle, FullTextPlain, OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"A Study\nJane Smith\nJ Test (2023)\nPMCID: PMC123 | PMID: 456\n",
+		"\nINTRODUCTION\n\nFirst paragraph.\n",
+		"\nParticipants\n\n• One\n• Two\n",
+		"\nFig. 1: Flow.\n",
+		"Group\tN\n",
+		"\nREFERENCES\n\n1. Bear MF. Theory. 2004.\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "not available") {
+		t.Error("unexpected missing-body notice")
+	}
+}
+
+func TestFormatFullText_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := FormatFullText(&buf, fullTextArticle, FullTextMarkdown, OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# A Study\n",
+		"\n## Abstract\n\nBackground: Short.\n",
+		"\n## Introduction\n",
+		"\n### Participants\n\n- One\n- Two\n",
+		"\n**Fig. 1** Flow.\n",
+		"| Group | N |\n| --- | --- |\n| A\\|B | 2 |\n",
+		"\n## References\n\n1. Bear MF. Theory. 2004.\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatFullText_JSON(t *testing.T) {
+	for _, tc := range []struct {
+		format string
+		cfg    OutputConfig
+	}{
+		{FullTextJSON, OutputConfig{}},
+		{FullTextPlain, OutputConfig{JSON: true}},
+	} {
+		var buf bytes.Buffer
+		if err := FormatFullText(&buf, fullTextArticle, tc.format, tc.cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got pmc.Article
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if got.PMCID != "PMC123" || len(got.Sections) != 2 {
+			t.Errorf("unexpected decoded article: %+v", got)
+		}
+	}
+}
+
+func TestFormatFullText_NoBody(t *testing.T) {
+	var buf bytes.Buffer
+	a := &pmc.Article{PMCID: "PMC1", Title: "Restricted"}
+	if err := FormatFullText(&buf, a, FullTextPlain, OutputConfig{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Full text not available") {
+		t.Errorf("expected missing-body notice, got:\n%s", buf.String())
+	}
+}
+
+func TestFormatFullText_UnsupportedFormat(t *testing.T) {
+	if err := FormatFullText(&bytes.Buffer{}, fullTextArticle, "pdf", OutputConfig{}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
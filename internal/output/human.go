@@ -3,12 +3,15 @@ package output
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
+	lgtree "github.com/charmbracelet/lipgloss/tree"
 	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/idconv"
 	"github.com/henrybloomingdale/pubmed-cli/internal/mesh"
 )
 
@@ -21,6 +24,7 @@ var (
 	green      = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
 	yellow     = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
 	magenta    = lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+	red        = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("1"))
 	labelStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("4"))
 	boxStyle   = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
@@ -41,11 +45,41 @@ func truncate(s string, maxLen int) string {
 	return string(runes[:maxLen-1]) + "…"
 }
 
+// retractedTag marks retracted articles in tables and title cards.
+const retractedTag = "RETRACTED"
+
+// tableTitle truncates an article title for a table cell, prefixing a
+// highlighted tag when the article has been retracted.
+func tableTitle(a eutils.Article, maxLen int, style lipgloss.Style) string {
+	if !a.Retracted {
+		return style.Render(truncate(a.Title, maxLen))
+	}
+	return red.Render(retractedTag) + " " + style.Render(truncate(a.Title, maxLen-len(retractedTag)-1))
+}
+
 // --- Search ---
 
+func formatExpansionHuman(w io.Writer, exp *mesh.Expansion) {
+	fmt.Fprintf(w, "🧭 %s\n", bold.Render("MeSH expansion"))
+	if len(exp.Mappings) == 0 {
+		fmt.Fprintf(w, "   %s\n", dim.Render("no free-text terms"))
+	}
+	for _, m := range exp.Mappings {
+		if m.Mapped() {
+			fmt.Fprintf(w, "   %s %s → %s %s\n", green.Render("✓"), m.Term, yellow.Render(m.Descriptor), dim.Render(m.UI))
+		} else {
+			fmt.Fprintf(w, "   %s %s %s\n", dim.Render("·"), m.Term, dim.Render("kept as free text: "+m.Reason))
+		}
+	}
+	fmt.Fprintf(w, "   %s %s\n\n", labelStyle.Render("Expanded query:"), exp.Query)
+}
+
 func formatSearchHuman(w io.Writer, result *eutils.SearchResult, articles []eutils.Article) error {
 	if result.Count == 0 {
 		fmt.Fprintln(w, "🔬 No results found.")
+		if result.SuggestedQuery != "" {
+			fmt.Fprintf(w, "   Did you mean: %s\n", bold.Render(result.SuggestedQuery))
+		}
 		return nil
 	}
 
@@ -56,6 +90,9 @@ func formatSearchHuman(w io.Writer, result *eutils.SearchResult, articles []euti
 	}
 	fmt.Fprintln(w, bold.Render(header))
 
+	if result.SuggestedQuery != "" {
+		fmt.Fprintf(w, "   Showing results for: %s\n", bold.Render(result.SuggestedQuery))
+	}
 	if result.QueryTranslation != "" {
 		fmt.Fprintf(w, "   Query: %s\n", dim.Render(result.QueryTranslation))
 	}
@@ -81,7 +118,7 @@ func formatSearchHuman(w io.Writer, result *eutils.SearchResult, articles []euti
 			}
 			rows = append(rows, []string{
 				cyan.Render(a.PMID),
-				bold.Render(truncate(a.Title, 50)),
+				tableTitle(a, 50, bold),
 				a.Year,
 				pubType,
 			})
@@ -104,7 +141,7 @@ func formatSearchHuman(w io.Writer, result *eutils.SearchResult, articles []euti
 		// Just PMIDs
 		var rows [][]string
 		for i, id := range result.IDs {
-			rows = append(rows, []string{fmt.Sprintf("%d", i+1), cyan.Render(id)})
+			rows = append(rows, []string{fmt.Sprintf("%d", result.Offset+i+1), cyan.Render(id)})
 		}
 
 		t := table.New().
@@ -146,6 +183,9 @@ func formatArticlesHuman(w io.Writer, articles []eutils.Article, full bool) erro
 		if a.Year != "" {
 			meta += dim.Render(" · ") + a.Year
 		}
+		if a.Retracted {
+			meta += dim.Render(" · ") + red.Render("⚠ "+retractedTag)
+		}
 		card := titleLine + "\n" + meta
 		fmt.Fprintln(w, boxStyle.Render(card))
 		fmt.Fprintln(w)
@@ -172,7 +212,11 @@ func formatArticlesHuman(w io.Writer, articles []eutils.Article, full bool) erro
 		if a.Year != "" {
 			citation += " (" + a.Year + ")"
 		}
-		fmt.Fprintf(w, "  %s %s\n", labelStyle.Render("Journal:"), citation)
+		if a.Book != nil {
+			fmt.Fprintf(w, "  %s %s\n", labelStyle.Render("Book:"), bookCitation(a))
+		} else {
+			fmt.Fprintf(w, "  %s %s\n", labelStyle.Render("Journal:"), citation)
+		}
 
 		if a.DOI != "" {
 			fmt.Fprintf(w, "  %s %s\n", labelStyle.Render("DOI:"), yellow.Render(a.DOI))
@@ -180,13 +224,22 @@ func formatArticlesHuman(w io.Writer, articles []eutils.Article, full bool) erro
 		if len(a.PublicationTypes) > 0 {
 			fmt.Fprintf(w, "  %s %s\n", labelStyle.Render("Type:"), strings.Join(a.PublicationTypes, ", "))
 		}
+		for _, c := range a.Corrections {
+			notice := correctionNotice(c)
+			if c.Type == eutils.CorrectionRetraction || c.Type == eutils.CorrectionExpressionOfConcern {
+				notice = red.Render(notice)
+			} else {
+				notice = yellow.Render(notice)
+			}
+			fmt.Fprintf(w, "  %s %s\n", labelStyle.Render("Notice:"), notice)
+		}
 
 		// MeSH terms
 		if len(a.MeSHTerms) > 0 {
 			var terms []string
 			for _, m := range a.MeSHTerms {
 				t := m.Descriptor
-				if m.MajorTopic {
+				if m.Major() {
 					t = green.Render("*" + t)
 				}
 				terms = append(terms, t)
@@ -215,20 +268,21 @@ func formatArticlesHuman(w io.Writer, articles []eutils.Article, full bool) erro
 
 // --- Links ---
 
-func formatLinksHuman(w io.Writer, result *eutils.LinkResult, linkType string) error {
-	emoji := "🔗"
-	title := linkType
+// linkHeading returns the emoji and title for a link type.
+func linkHeading(linkType string) (string, string) {
 	switch linkType {
 	case "cited-by":
-		emoji = "📚"
-		title = "Cited By"
+		return "📚", "Cited By"
 	case "references":
-		emoji = "📖"
-		title = "References"
+		return "📖", "References"
 	case "related":
-		emoji = "🔍"
-		title = "Related Articles"
+		return "🔍", "Related Articles"
 	}
+	return "🔗", linkType
+}
+
+func formatLinksHuman(w io.Writer, result *eutils.LinkResult, linkType string) error {
+	emoji, title := linkHeading(linkType)
 
 	if len(result.Links) == 0 {
 		fmt.Fprintf(w, "%s No %s results for PMID %s.\n", emoji, linkType, cyan.Render(result.SourceID))
@@ -288,19 +342,7 @@ func formatLinksHuman(w io.Writer, result *eutils.LinkResult, linkType string) e
 
 // FormatLinksWithArticles writes link results with full article details for human mode.
 func FormatLinksWithArticles(w io.Writer, result *eutils.LinkResult, articles []eutils.Article, articleMap map[string]eutils.Article, linkType string, limit int) error {
-	emoji := "🔗"
-	title := linkType
-	switch linkType {
-	case "cited-by":
-		emoji = "📚"
-		title = "Cited By"
-	case "references":
-		emoji = "📖"
-		title = "References"
-	case "related":
-		emoji = "🔍"
-		title = "Related Articles"
-	}
+	emoji, title := linkHeading(linkType)
 
 	if len(result.Links) == 0 {
 		fmt.Fprintf(w, "%s No %s results for PMID %s.\n", emoji, linkType, cyan.Render(result.SourceID))
@@ -336,7 +378,7 @@ func FormatLinksWithArticles(w io.Writer, result *eutils.LinkResult, articles []
 		titleText := dim.Render("(not found)")
 		yearText := ""
 		if found {
-			titleText = truncate(article.Title, 55)
+			titleText = tableTitle(article, 55, lipgloss.NewStyle())
 			yearText = article.Year
 		}
 
@@ -377,6 +419,53 @@ func FormatLinksWithArticles(w io.Writer, result *eutils.LinkResult, articles []
 	return nil
 }
 
+func formatLinkResultsHuman(w io.Writer, results []eutils.LinkResult, linkType string) error {
+	for i := range results {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if err := formatLinksHuman(w, &results[i], linkType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatAggregatedLinksHuman(w io.Writer, sourceIDs []string, links []eutils.AggregatedLink, linkType string, articleMap map[string]eutils.Article, limit int) error {
+	emoji, title := linkHeading(linkType)
+	if len(links) == 0 {
+		fmt.Fprintf(w, "%s No %s results for %d PMIDs.\n", emoji, linkType, len(sourceIDs))
+		return nil
+	}
+
+	showing := min(limit, len(links))
+	fmt.Fprintf(w, "%s %s across %s PMIDs (%d unique, showing %d)\n\n",
+		emoji,
+		bold.Render(title),
+		cyan.Render(fmt.Sprintf("%d", len(sourceIDs))),
+		len(links),
+		showing)
+
+	var rows [][]string
+	for i, link := range links[:showing] {
+		titleText := dim.Render("(not found)")
+		yearText := ""
+		if article, found := articleMap[link.ID]; found {
+			titleText = tableTitle(article, 55, lipgloss.NewStyle())
+			yearText = article.Year
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			cyan.Render(link.ID),
+			green.Render(fmt.Sprintf("%d/%d", link.Count, len(sourceIDs))),
+			titleText,
+			yearText,
+		})
+	}
+	fmt.Fprintln(w, infoTable([]string{"#", "PMID", "Sources", "Title", "Year"}, rows).Render())
+	return nil
+}
+
 // --- MeSH ---
 
 func formatMeSHHuman(w io.Writer, record *mesh.MeSHRecord) error {
@@ -414,6 +503,13 @@ func formatMeSHHuman(w io.Writer, record *mesh.MeSHRecord) error {
 		fmt.Fprintln(w)
 	}
 
+	// Allowable qualifiers (subheadings)
+	if len(record.AllowableQualifiers) > 0 {
+		fmt.Fprintf(w, "  %s ", labelStyle.Render("Qualifiers:"))
+		fmt.Fprintln(w, dim.Render(strings.Join(qualifierLabels(record.AllowableQualifiers), ", ")))
+		fmt.Fprintln(w)
+	}
+
 	// Annotation
 	if record.Annotation != "" {
 		fmt.Fprintf(w, "  %s %s\n", labelStyle.Render("Annotation:"), record.Annotation)
@@ -422,6 +518,189 @@ func formatMeSHHuman(w io.Writer, record *mesh.MeSHRecord) error {
 	return nil
 }
 
+func formatMeSHCandidatesHuman(w io.Writer, candidates []mesh.Candidate) error {
+	fmt.Fprintln(w, bold.Render(fmt.Sprintf("🏷️  %d MeSH candidates", len(candidates))))
+	fmt.Fprintln(w)
+
+	var rows [][]string
+	for i, c := range candidates {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			cyan.Render(c.UI),
+			truncate(c.Name, 45),
+			string(c.Type),
+			strings.ReplaceAll(string(c.Match), "_", " "),
+			truncate(strings.Join(c.TreeNumbers, ", "), 30),
+		})
+	}
+	fmt.Fprintln(w, infoTable([]string{"#", "UI", "Name", "Type", "Match", "Tree Numbers"}, rows).Render())
+	return nil
+}
+
+func formatMeSHTreeHuman(w io.Writer, tree *mesh.Tree, parents, siblings []mesh.TreeNode) error {
+	fmt.Fprintf(w, "🌳 %s  %s\n\n", bold.Render(tree.Name), dim.Render(tree.UI))
+
+	for _, group := range []struct {
+		label string
+		nodes []mesh.TreeNode
+	}{{"Parents:", parents}, {"Siblings:", siblings}} {
+		if len(group.nodes) == 0 {
+			continue
+		}
+		names := make([]string, len(group.nodes))
+		for i, n := range group.nodes {
+			names[i] = fmt.Sprintf("%s %s", yellow.Render(n.Name), dim.Render(n.TreeNumber))
+		}
+		fmt.Fprintf(w, "  %s %s\n", labelStyle.Render(group.label), strings.Join(names, ", "))
+	}
+	if len(parents) > 0 || len(siblings) > 0 {
+		fmt.Fprintln(w)
+	}
+
+	for _, root := range tree.Roots {
+		t := meshSubtree(tree, root).
+			Enumerator(lgtree.RoundedEnumerator).
+			EnumeratorStyle(magenta.PaddingRight(1)).
+			RootStyle(bold)
+		fmt.Fprintln(w, t.String())
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// meshSubtree builds the lipgloss tree below tree number tn.
+func meshSubtree(tree *mesh.Tree, tn string) *lgtree.Tree {
+	node := tree.Nodes[tn]
+	t := lgtree.Root(fmt.Sprintf("%s %s", node.Name, dim.Render(tn)))
+	for _, child := range tree.Children[tn] {
+		t.Child(meshSubtree(tree, child))
+	}
+	return t
+}
+
+// --- EInfo ---
+
+func formatFieldsHuman(w io.Writer, info *eutils.DBInfo) error {
+	fmt.Fprintln(w, bold.Render(fmt.Sprintf("🔎 Search fields for %s", info.Name)))
+	fmt.Fprintln(w)
+
+	var rows [][]string
+	for _, f := range info.Fields {
+		if f.Hidden {
+			continue
+		}
+		rows = append(rows, []string{
+			cyan.Render(f.Tag()),
+			f.FullName,
+			formatCount(f.TermCount),
+			truncate(f.Description, 60),
+		})
+	}
+	fmt.Fprintln(w, infoTable([]string{"Tag", "Field", "Terms", "Description"}, rows).Render())
+	return nil
+}
+
+func formatLinkNamesHuman(w io.Writer, info *eutils.DBInfo) error {
+	fmt.Fprintln(w, bold.Render(fmt.Sprintf("🔗 Link names from %s", info.Name)))
+	fmt.Fprintln(w)
+
+	var rows [][]string
+	for _, l := range info.Links {
+		rows = append(rows, []string{
+			cyan.Render(l.Name),
+			magenta.Render(l.DbTo),
+			truncate(l.Description, 60),
+		})
+	}
+	fmt.Fprintln(w, infoTable([]string{"Link Name", "To", "Description"}, rows).Render())
+	return nil
+}
+
+// --- Cross-database links ---
+
+func formatLinkSetsHuman(w io.Writer, sets []eutils.LinkSet, records map[string]eutils.RecordSummary) error {
+	for i, set := range sets {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if len(set.LinkSetDBs) == 0 {
+			fmt.Fprintln(w, dim.Render(fmt.Sprintf("No links for %s.", linkSourceLabel(set))))
+			continue
+		}
+		fmt.Fprintln(w, bold.Render(fmt.Sprintf("🔗 Links for %s", linkSourceLabel(set))))
+		for _, db := range set.LinkSetDBs {
+			fmt.Fprintln(w)
+			fmt.Fprintf(w, "%s %s %s\n", cyan.Render(db.LinkName), dim.Render("→"), magenta.Render(fmt.Sprintf("%s (%d)", db.DbTo, len(db.Links))))
+
+			var rows [][]string
+			for _, l := range db.Links {
+				r := records[l.ID]
+				rows = append(rows, []string{l.ID, green.Render(r.Accession), truncate(r.Title, 60), dim.Render(r.Organism)})
+			}
+			fmt.Fprintln(w, infoTable([]string{"ID", "Accession", "Title", "Organism"}, rows).Render())
+		}
+	}
+	return nil
+}
+
+// --- ID conversion ---
+
+func formatIDRecordsHuman(w io.Writer, records []idconv.Record) error {
+	mapped := 0
+	var rows [][]string
+	for _, r := range records {
+		if r.Error == "" {
+			mapped++
+		}
+		note := dim.Render(r.Source)
+		if r.Error != "" {
+			note = red.Render(r.Error)
+		}
+		rows = append(rows, []string{
+			truncate(r.Input, 40),
+			cyan.Render(r.PMID),
+			green.Render(r.PMCID),
+			truncate(r.DOI, 40),
+			r.MID,
+			note,
+		})
+	}
+	fmt.Fprintln(w, bold.Render(fmt.Sprintf("🔁 ID conversion: %d of %d mapped", mapped, len(records))))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, infoTable([]string{"Input", "PMID", "PMCID", "DOI", "MID", "Source"}, rows).Render())
+	return nil
+}
+
+func infoTable(headers []string, rows [][]string) *table.Table {
+	return table.New().
+		Headers(headers...).
+		Rows(rows...).
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("4"))
+			}
+			return lipgloss.NewStyle()
+		})
+}
+
+// formatCount renders n with thousands separators.
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	if n < 0 {
+		return s
+	}
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // wordWrap wraps text at the given width, breaking at spaces.
 func wordWrap(text string, width int) string {
 	words := strings.Fields(text)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/idconv"
 	"github.com/henrybloomingdale/pubmed-cli/internal/mesh"
 )
 
@@ -140,6 +141,58 @@ func TestFormatArticlesHuman_Card(t *testing.T) {
 	}
 }
 
+func TestFormatArticlesHuman_RetractedNotices(t *testing.T) {
+	articles := []eutils.Article{
+		{
+			PMID:      "31234567",
+			Title:     "Plasma biomarkers predict treatment response",
+			Year:      "2019",
+			Retracted: true,
+			Corrections: []eutils.Correction{
+				{Type: eutils.CorrectionRetraction, Source: "Transl Psychiatry. 2021;11(1):150", PMID: "33622222"},
+				{Type: eutils.CorrectionErratum, Source: "Transl Psychiatry. 2019;9(1):200"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatArticlesHuman(&buf, articles, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"RETRACTED",
+		"Retraction: Transl Psychiatry. 2021;11(1):150 (PMID 33622222)",
+		"Erratum: Transl Psychiatry. 2019;9(1):200",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatSearchHuman_RetractedTag(t *testing.T) {
+	result := &eutils.SearchResult{Count: 2, IDs: []string{"111", "222"}}
+	articles := []eutils.Article{
+		{PMID: "111", Title: "Retracted trial", Year: "2019", Retracted: true},
+		{PMID: "222", Title: "Sound trial", Year: "2020"},
+	}
+
+	var buf bytes.Buffer
+	if err := formatSearchHuman(&buf, result, articles); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Count(out, "RETRACTED") != 1 {
+		t.Errorf("expected exactly one RETRACTED tag, got:\n%s", out)
+	}
+	if !strings.Contains(out, "RETRACTED Retracted trial") {
+		t.Errorf("expected tag before retracted title, got:\n%s", out)
+	}
+}
+
 func TestFormatArticlesHuman_TruncatedAbstract(t *testing.T) {
 	longAbstract := strings.Repeat("Word ", 200) // ~1000 chars
 	articles := []eutils.Article{
@@ -240,6 +293,9 @@ func TestFormatMeSHHuman(t *testing.T) {
 		TreeNumbers: []string{"C10.597.606.360", "C16.320.322"},
 		EntryTerms:  []string{"FXS", "Martin-Bell Syndrome", "Fra(X)"},
 		Annotation:  "Do not confuse with fragile X tremor.",
+		AllowableQualifiers: []mesh.Qualifier{
+			{UI: "Q000235", Name: "genetics", Abbreviation: "GE"},
+		},
 	}
 
 	var buf bytes.Buffer
@@ -264,4 +320,106 @@ func TestFormatMeSHHuman(t *testing.T) {
 	if !strings.Contains(out, "CGG repeats") {
 		t.Error("expected scope note content in output")
 	}
+	if !strings.Contains(out, "genetics (GE)") {
+		t.Error("expected allowable qualifier in output")
+	}
+}
+
+func TestFormatMeSHTreeHuman(t *testing.T) {
+	tree, parents, _ := sampleMeSHTree()
+
+	var buf bytes.Buffer
+	if err := formatMeSHTreeHuman(&buf, tree, parents, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Neurodevelopmental Disorders", "├── Down Syndrome", "╰── Fragile X Syndrome", "C10.597.606.360.320"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Siblings") {
+		t.Error("expected no siblings line without siblings")
+	}
+}
+
+func TestFormatFieldsHuman(t *testing.T) {
+	info := &eutils.DBInfo{
+		Name: "pubmed",
+		Fields: []eutils.FieldInfo{
+			{Name: "DP", FullName: "Publication Date", TermCount: 45678},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := formatFieldsHuman(&buf, info); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "[dp]") || !strings.Contains(out, "45,678") {
+		t.Errorf("expected tag and formatted count, got:\n%s", out)
+	}
+}
+
+func TestFormatAggregatedLinksHuman(t *testing.T) {
+	links := []eutils.AggregatedLink{
+		{ID: "10", Count: 2, Sources: []string{"1", "2"}},
+		{ID: "11", Count: 1, Sources: []string{"1"}},
+	}
+	articles := map[string]eutils.Article{"10": {PMID: "10", Title: "Shared Citation", Year: "2024"}}
+
+	var buf bytes.Buffer
+	if err := FormatAggregatedLinks(&buf, []string{"1", "2"}, links, "cited-by", articles, 1, OutputConfig{Human: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"across", "2 unique, showing 1", "2/2", "Shared Citation"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "11") {
+		t.Errorf("expected rows beyond the limit to be omitted, got:\n%s", out)
+	}
+}
+
+func TestFormatLinkSetsHuman(t *testing.T) {
+	sets, records := testLinkSets()
+	var buf bytes.Buffer
+	if err := FormatLinkSets(&buf, sets, records, OutputConfig{Human: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Links for PMID 37286542", "pubmed_gene", "FMR1", "Homo sapiens", "14265", "No links for PMID 38123456"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatIDRecordsHuman(t *testing.T) {
+	records := []idconv.Record{
+		{Input: "456", PMID: "456", PMCID: "PMC123", Source: idconv.SourceIDConv},
+		{Input: "10.1/missing", Error: "not found"},
+	}
+
+	var buf bytes.Buffer
+	if err := formatIDRecordsHuman(&buf, records); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"1 of 2 mapped", "PMC123", "not found"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := map[int]string{0: "0", 999: "999", 1000: "1,000", 38512345: "38,512,345"}
+	for in, want := range tests {
+		if got := formatCount(in); got != want {
+			t.Errorf("formatCount(%d) = %q, want %q", in, got, want)
+		}
+	}
 }
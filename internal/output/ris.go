@@ -19,29 +19,7 @@ func writeArticlesRIS(path string, articles []eutils.Article) error {
 
 	w := bufio.NewWriter(f)
 	for i, a := range articles {
-		writeRISTag(w, "TY", "JOUR")
-		writeRISTag(w, "TI", a.Title)
-
-		for _, au := range a.Authors {
-			writeRISTag(w, "AU", risAuthor(au))
-		}
-
-		writeRISTag(w, "PY", a.Year)
-		writeRISTag(w, "JO", a.Journal)
-		writeRISTag(w, "VL", a.Volume)
-		writeRISTag(w, "IS", a.Issue)
-
-		startPage, endPage := splitPages(a.Pages)
-		writeRISTag(w, "SP", startPage)
-		writeRISTag(w, "EP", endPage)
-
-		writeRISTag(w, "DO", a.DOI)
-		writeRISTag(w, "AB", a.Abstract)
-		if a.PMID != "" {
-			writeRISTag(w, "ID", "PMID:"+a.PMID)
-			writeRISTag(w, "UR", "https://pubmed.ncbi.nlm.nih.gov/"+a.PMID+"/")
-		}
-		writeRISTag(w, "ER", "")
+		writeRISRecord(w, a)
 
 		if i < len(articles)-1 {
 			if _, err := w.WriteString("\n"); err != nil {
@@ -57,6 +35,79 @@ func writeArticlesRIS(path string, articles []eutils.Article) error {
 	return nil
 }
 
+// writeRISRecord writes one article as a RIS record ending in ER.
+func writeRISRecord(w *bufio.Writer, a eutils.Article) {
+	writeRISTag(w, "TY", risType(a))
+	writeRISTag(w, "TI", a.Title)
+
+	for _, au := range a.Authors {
+		writeRISTag(w, "AU", risAuthor(au))
+	}
+	if b := a.Book; b != nil {
+		if b.Type == eutils.BookTypeChapter {
+			writeRISTag(w, "T2", b.Title)
+		}
+		for _, ed := range b.Editors {
+			writeRISTag(w, "A2", risAuthor(ed))
+		}
+		writeRISTag(w, "T3", b.Collection)
+		writeRISTag(w, "PB", b.Publisher)
+		writeRISTag(w, "CY", b.PublisherLocation)
+		writeRISTag(w, "ET", b.Edition)
+		writeRISTag(w, "VL", b.Volume)
+		for _, isbn := range b.ISBNs {
+			writeRISTag(w, "SN", isbn)
+		}
+	}
+
+	writeRISTag(w, "PY", a.Year)
+	writeRISTag(w, "JO", a.Journal)
+	writeRISTag(w, "VL", a.Volume)
+	writeRISTag(w, "IS", a.Issue)
+
+	startPage, endPage := splitPages(a.Pages)
+	writeRISTag(w, "SP", startPage)
+	writeRISTag(w, "EP", endPage)
+
+	writeRISTag(w, "DO", a.DOI)
+	writeRISTag(w, "AB", a.Abstract)
+	for _, kw := range a.Keywords {
+		writeRISTag(w, "KW", kw)
+	}
+	for _, aff := range uniqueAffiliations(a.Authors) {
+		writeRISTag(w, "AD", aff)
+	}
+	for _, d := range a.ArticleDates {
+		if d.Type == "Electronic" {
+			writeRISTag(w, "DA", strings.ReplaceAll(d.Date, "-", "/"))
+			break
+		}
+	}
+	for _, g := range grantLabels(a.Grants) {
+		writeRISTag(w, "N1", "Funding: "+g)
+	}
+	if a.COIStatement != "" {
+		writeRISTag(w, "N1", "Conflict of interest: "+a.COIStatement)
+	}
+	if a.PMID != "" {
+		writeRISTag(w, "ID", "PMID:"+a.PMID)
+		writeRISTag(w, "UR", "https://pubmed.ncbi.nlm.nih.gov/"+a.PMID+"/")
+	}
+	writeRISTag(w, "ER", "")
+}
+
+// risType maps an article to its RIS reference type: CHAP or BOOK for
+// Bookshelf records, JOUR otherwise.
+func risType(a eutils.Article) string {
+	if a.Book == nil {
+		return "JOUR"
+	}
+	if a.Book.Type == eutils.BookTypeChapter {
+		return "CHAP"
+	}
+	return "BOOK"
+}
+
 func writeRISTag(w *bufio.Writer, tag, value string) {
 	if tag == "" {
 		return
@@ -84,6 +135,9 @@ func risAuthor(a eutils.Author) string {
 	}
 	last := strings.TrimSpace(a.LastName)
 	fore := strings.TrimSpace(a.ForeName)
+	if fore == "" {
+		fore = strings.TrimSpace(a.Initials)
+	}
 	if last == "" {
 		return fore
 	}
@@ -93,6 +147,26 @@ func risAuthor(a eutils.Author) string {
 	return last + ", " + fore
 }
 
+// uniqueAffiliations lists every author affiliation once, in author order.
+func uniqueAffiliations(authors []eutils.Author) []string {
+	seen := make(map[string]struct{})
+	var out []string
+	for _, au := range authors {
+		affs := au.Affiliations
+		if len(affs) == 0 && au.Affiliation != "" {
+			affs = []string{au.Affiliation}
+		}
+		for _, aff := range affs {
+			if _, ok := seen[aff]; ok {
+				continue
+			}
+			seen[aff] = struct{}{}
+			out = append(out, aff)
+		}
+	}
+	return out
+}
+
 func splitPages(pages string) (string, string) {
 	pages = strings.TrimSpace(pages)
 	if pages == "" {
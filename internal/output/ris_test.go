@@ -20,6 +20,7 @@ func TestWriteArticlesRIS(t *testing.T) {
 			Abstract: "Line one.\nLine two.",
 			Authors: []eutils.Author{
 				{LastName: "Smith", ForeName: "Jane"},
+				{LastName: "Doe", Initials: "JA"},
 				{CollectiveName: "PubMed CLI Consortium"},
 			},
 			Journal: "Journal of CLI Testing",
@@ -45,6 +46,7 @@ func TestWriteArticlesRIS(t *testing.T) {
 		"TY  - JOUR",
 		"TI  - Testing RIS Export",
 		"AU  - Smith, Jane",
+		"AU  - Doe, JA",
 		"AU  - PubMed CLI Consortium",
 		"PY  - 2026",
 		"JO  - Journal of CLI Testing",
@@ -65,6 +67,111 @@ func TestWriteArticlesRIS(t *testing.T) {
 	}
 }
 
+func TestWriteArticlesRIS_ExtendedFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "articles.ris")
+
+	articles := []eutils.Article{
+		{
+			PMID:  "37000111",
+			Title: "Metformin and gamma oscillations",
+			Authors: []eutils.Author{
+				{LastName: "Pedapati", ForeName: "Ernest V", Affiliations: []string{"CCHMC", "University of Cincinnati"}},
+				{LastName: "Erickson", ForeName: "Craig A", Affiliations: []string{"CCHMC"}},
+			},
+			Keywords:     []string{"EEG", "FMR1"},
+			Grants:       []eutils.Grant{{GrantID: "R01 HD093771", Agency: "NICHD NIH HHS"}},
+			ArticleDates: []eutils.ArticleDate{{Type: "Electronic", Date: "2023-06-05"}},
+			COIStatement: "None declared.",
+		},
+	}
+	if err := writeArticlesRIS(path, articles); err != nil {
+		t.Fatalf("unexpected error writing RIS: %v", err)
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read RIS output: %v", err)
+	}
+	out := string(body)
+
+	for _, want := range []string{
+		"KW  - EEG",
+		"KW  - FMR1",
+		"AD  - CCHMC",
+		"AD  - University of Cincinnati",
+		"DA  - 2023/06/05",
+		"N1  - Funding: NICHD NIH HHS R01 HD093771",
+		"N1  - Conflict of interest: None declared.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected RIS output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "AD  - CCHMC") != 1 {
+		t.Errorf("expected shared affiliation once, got:\n%s", out)
+	}
+}
+
+func TestWriteArticlesRIS_BookTypes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.ris")
+
+	articles := []eutils.Article{
+		{
+			PMID:    "20301558",
+			Title:   "FMR1 Disorders",
+			Year:    "1993",
+			Authors: []eutils.Author{{LastName: "Hunter", ForeName: "Jessica Ezzell"}},
+			Book: &eutils.BookInfo{
+				Type:              eutils.BookTypeChapter,
+				Title:             "GeneReviews",
+				Publisher:         "University of Washington, Seattle",
+				PublisherLocation: "Seattle (WA)",
+				Editors:           []eutils.Author{{LastName: "Adam", ForeName: "Margaret P"}},
+			},
+		},
+		{
+			PMID:  "21413016",
+			Title: "Dietary Reference Intakes for Calcium and Vitamin D",
+			Year:  "2011",
+			Book: &eutils.BookInfo{
+				Type:      eutils.BookTypeBook,
+				Title:     "Dietary Reference Intakes for Calcium and Vitamin D",
+				Publisher: "National Academies Press (US)",
+				ISBNs:     []string{"9780309163941"},
+			},
+		},
+		{PMID: "35999876", Title: "Journal article", Journal: "J Neurosci"},
+	}
+	if err := writeArticlesRIS(path, articles); err != nil {
+		t.Fatalf("unexpected error writing RIS: %v", err)
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read RIS output: %v", err)
+	}
+	records := strings.Split(strings.TrimSpace(string(body)), "\n\n")
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+
+	chapter, book, journal := records[0], records[1], records[2]
+	for _, want := range []string{"TY  - CHAP", "TI  - FMR1 Disorders", "T2  - GeneReviews", "A2  - Adam, Margaret P", "PB  - University of Washington, Seattle", "CY  - Seattle (WA)"} {
+		if !strings.Contains(chapter, want) {
+			t.Errorf("chapter record missing %q:\n%s", want, chapter)
+		}
+	}
+	for _, want := range []string{"TY  - BOOK", "PB  - National Academies Press (US)", "SN  - 9780309163941"} {
+		if !strings.Contains(book, want) {
+			t.Errorf("book record missing %q:\n%s", want, book)
+		}
+	}
+	if strings.Contains(book, "T2  -") {
+		t.Errorf("whole-book record should not have a secondary title:\n%s", book)
+	}
+	if !strings.HasPrefix(journal, "TY  - JOUR") {
+		t.Errorf("expected journal record type JOUR:\n%s", journal)
+	}
+}
+
 func TestSplitPages(t *testing.T) {
 	tests := []struct {
 		in     string
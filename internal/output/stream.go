@@ -0,0 +1,89 @@
+package output
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+)
+
+// Streaming export formats accepted by NewArticleStream.
+const (
+	StreamNDJSON = "ndjson"
+	StreamCSV    = "csv"
+	StreamRIS    = "ris"
+)
+
+// ArticleStream writes articles one at a time in NDJSON, CSV or RIS, so an
+// export of any size needs memory for only a single record. Output is
+// buffered; call Close to flush it.
+type ArticleStream struct {
+	format string
+	buf    *bufio.Writer
+	csv    *csv.Writer
+	enc    *json.Encoder
+	count  int
+}
+
+// NewArticleStream returns a stream that writes format to w. CSV output
+// starts with the same header row as --csv article exports.
+func NewArticleStream(w io.Writer, format string) (*ArticleStream, error) {
+	s := &ArticleStream{format: strings.ToLower(format), buf: bufio.NewWriter(w)}
+	switch s.format {
+	case StreamNDJSON:
+		s.enc = json.NewEncoder(s.buf)
+	case StreamCSV:
+		s.csv = csv.NewWriter(s.buf)
+		if err := s.csv.Write(articleCSVHeader); err != nil {
+			return nil, fmt.Errorf("writing CSV header: %w", err)
+		}
+	case StreamRIS:
+	default:
+		return nil, fmt.Errorf("unsupported stream format %q (use ndjson, csv, or ris)", format)
+	}
+	return s, nil
+}
+
+// Write appends one article to the stream.
+func (s *ArticleStream) Write(a eutils.Article) error {
+	var err error
+	switch s.format {
+	case StreamNDJSON:
+		err = s.enc.Encode(a)
+	case StreamCSV:
+		err = s.csv.Write(articleCSVRow(a))
+	case StreamRIS:
+		if s.count > 0 {
+			_, err = s.buf.WriteString("\n")
+		}
+		writeRISRecord(s.buf, a)
+	}
+	if err != nil {
+		return fmt.Errorf("writing %s record: %w", s.format, err)
+	}
+	s.count++
+	return nil
+}
+
+// Count returns the number of articles written so far.
+func (s *ArticleStream) Count() int {
+	return s.count
+}
+
+// Close flushes buffered output. It does not close the underlying writer.
+func (s *ArticleStream) Close() error {
+	if s.csv != nil {
+		s.csv.Flush()
+		if err := s.csv.Error(); err != nil {
+			return fmt.Errorf("flushing CSV output: %w", err)
+		}
+	}
+	if err := s.buf.Flush(); err != nil {
+		return fmt.Errorf("flushing %s output: %w", s.format, err)
+	}
+	return nil
+}
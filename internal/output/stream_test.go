@@ -0,0 +1,88 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+)
+
+var streamArticles = []eutils.Article{
+	{PMID: "111", Title: "First, with a comma", Year: "2024", Authors: []eutils.Author{{LastName: "Smith", ForeName: "John"}}},
+	{PMID: "222", Title: "Second", Year: "2023"},
+}
+
+func writeStream(t *testing.T, format string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	s, err := NewArticleStream(&buf, format)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, a := range streamArticles {
+		if err := s.Write(a); err != nil {
+			t.Fatalf("unexpected write error: %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+	if s.Count() != len(streamArticles) {
+		t.Errorf("expected count %d, got %d", len(streamArticles), s.Count())
+	}
+	return buf.String()
+}
+
+func TestArticleStream_NDJSON(t *testing.T) {
+	out := writeStream(t, StreamNDJSON)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), out)
+	}
+	var a eutils.Article
+	if err := json.Unmarshal([]byte(lines[1]), &a); err != nil {
+		t.Fatalf("line is not valid JSON: %v", err)
+	}
+	if a.PMID != "222" {
+		t.Errorf("expected PMID 222, got %q", a.PMID)
+	}
+}
+
+func TestArticleStream_CSV(t *testing.T) {
+	out := writeStream(t, "CSV")
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d", len(rows))
+	}
+	if strings.Join(rows[0], ",") != strings.Join(articleCSVHeader, ",") {
+		t.Errorf("unexpected header: %v", rows[0])
+	}
+	if rows[1][1] != "First, with a comma" || rows[1][2] != "John Smith" {
+		t.Errorf("unexpected first row: %v", rows[1])
+	}
+}
+
+func TestArticleStream_RIS(t *testing.T) {
+	out := writeStream(t, StreamRIS)
+	if strings.Count(out, "TY  - JOUR") != 2 || strings.Count(out, "ER  -") != 2 {
+		t.Errorf("expected 2 RIS records, got:\n%s", out)
+	}
+	if !strings.Contains(out, "ER  -\n\nTY  - JOUR") {
+		t.Errorf("expected blank line between records, got:\n%s", out)
+	}
+	if strings.HasPrefix(out, "\n") || strings.HasSuffix(out, "\n\n") {
+		t.Errorf("unexpected leading or trailing separator:\n%q", out)
+	}
+}
+
+func TestArticleStream_UnsupportedFormat(t *testing.T) {
+	if _, err := NewArticleStream(&bytes.Buffer{}, "xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
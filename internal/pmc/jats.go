@@ -0,0 +1,458 @@
+package pmc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// node is a minimal XML element tree. JATS mixes text and inline markup
+// freely (<italic>, <xref>, <sup>), which struct unmarshalling cannot keep
+// in document order, so the parser builds a tree and walks it instead.
+// Text nodes have an empty name.
+type node struct {
+	name     string
+	attrs    []xml.Attr
+	children []*node
+	text     string
+}
+
+// blockElements are rendered with surrounding whitespace so that adjacent
+// blocks such as a caption's <title> and <p> do not run together.
+var blockElements = map[string]bool{
+	"p": true, "title": true, "label": true, "caption": true, "sec": true,
+	"list-item": true, "td": true, "th": true, "tr": true, "ref": true,
+	"disp-quote": true, "def-item": true, "term": true, "def": true,
+}
+
+// floatElements are collected separately and left out of paragraph text.
+var floatElements = map[string]bool{
+	"fig": true, "fig-group": true, "table-wrap": true, "table-wrap-group": true,
+}
+
+// citationElements are the JATS elements that hold a single citation.
+var citationElements = []string{"mixed-citation", "element-citation", "citation", "nlm-citation"}
+
+// ParseJATS parses a PMC EFetch response (a pmc-articleset or a bare JATS
+// article) into an Article.
+func ParseJATS(data []byte) (*Article, error) {
+	root, err := parseTree(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JATS XML: %w", err)
+	}
+	art := root
+	if art.name != "article" {
+		found := root.find("article")
+		if len(found) == 0 {
+			return nil, ErrNoArticle
+		}
+		art = found[0]
+	}
+
+	a := &Article{}
+	front := art.child("front")
+	meta := front.child("article-meta")
+	for _, id := range meta.childrenNamed("article-id") {
+		value := id.textContent()
+		switch id.attr("pub-id-type") {
+		case "pmc", "pmcid":
+			if norm, err := NormalizePMCID(value); err == nil {
+				a.PMCID = norm
+			}
+		case "pmid":
+			a.PMID = value
+		case "doi":
+			a.DOI = value
+		}
+	}
+	a.Title = meta.child("title-group").child("article-title").textContent()
+
+	journalMeta := front.child("journal-meta")
+	a.Journal = journalMeta.child("journal-title-group").child("journal-title").textContent()
+	if a.Journal == "" {
+		a.Journal = journalMeta.child("journal-title").textContent()
+	}
+
+	for _, contrib := range meta.find("contrib") {
+		if t := contrib.attr("contrib-type"); t != "" && t != "author" {
+			continue
+		}
+		if name := contrib.child("name"); name != nil {
+			full := strings.TrimSpace(name.child("given-names").textContent() + " " + name.child("surname").textContent())
+			if full != "" {
+				a.Authors = append(a.Authors, full)
+			}
+		} else if collab := contrib.child("collab"); collab != nil {
+			a.Authors = append(a.Authors, collab.textContent())
+		}
+	}
+
+	for _, pd := range meta.childrenNamed("pub-date") {
+		if y := pd.child("year").textContent(); y != "" {
+			a.Year = y
+			break
+		}
+	}
+
+	for _, abs := range meta.childrenNamed("abstract") {
+		if abs.attr("abstract-type") == "" {
+			a.Abstract = abstractText(abs)
+			break
+		}
+	}
+
+	if license := meta.child("permissions").child("license"); license != nil {
+		a.License = license.attr("href")
+		if a.License == "" {
+			a.License = license.textContent()
+		}
+	}
+
+	if body := art.child("body"); body != nil {
+		a.Sections = bodySections(body)
+	}
+
+	for _, container := range []*node{art.child("body"), art.child("floats-group"), art.child("back")} {
+		for _, fig := range container.find("fig") {
+			a.Figures = append(a.Figures, Figure{
+				ID:      fig.attr("id"),
+				Label:   fig.child("label").textContent(),
+				Caption: fig.child("caption").textContent(),
+			})
+		}
+		for _, tw := range container.find("table-wrap") {
+			a.Tables = append(a.Tables, convertTable(tw))
+		}
+	}
+
+	for _, ref := range art.child("back").find("ref") {
+		a.References = append(a.References, convertReference(ref))
+	}
+
+	return a, nil
+}
+
+// abstractText joins abstract paragraphs, prefixing structured sections
+// with their titles ("Background: ...").
+func abstractText(abs *node) string {
+	var parts []string
+	for _, c := range abs.children {
+		switch c.name {
+		case "p":
+			if t := c.textContent(); t != "" {
+				parts = append(parts, t)
+			}
+		case "sec":
+			text := joinParagraphs(c.childrenNamed("p"))
+			if title := c.child("title").textContent(); title != "" {
+				text = title + ": " + text
+			}
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// bodySections converts the body's sections. Paragraphs that sit directly
+// in <body> are gathered into a leading untitled section.
+func bodySections(body *node) []Section {
+	var (
+		sections []Section
+		loose    Section
+	)
+	for _, c := range body.children {
+		switch c.name {
+		case "sec":
+			sections = append(sections, convertSection(c))
+		default:
+			if p := paragraphText(c); p != "" {
+				loose.Paragraphs = append(loose.Paragraphs, p)
+			}
+		}
+	}
+	if len(loose.Paragraphs) > 0 {
+		sections = append([]Section{loose}, sections...)
+	}
+	return sections
+}
+
+func convertSection(n *node) Section {
+	s := Section{ID: n.attr("id"), Title: n.child("title").textContent()}
+	for _, c := range n.children {
+		if c.name == "sec" {
+			s.Sections = append(s.Sections, convertSection(c))
+			continue
+		}
+		if p := paragraphText(c); p != "" {
+			s.Paragraphs = append(s.Paragraphs, p)
+		}
+	}
+	return s
+}
+
+// paragraphText renders a paragraph-level element, or "" for anything
+// else. List items are rendered one per line with a bullet.
+func paragraphText(n *node) string {
+	switch n.name {
+	case "p", "disp-quote", "statement":
+		return n.textContent()
+	case "list":
+		var items []string
+		for _, item := range n.childrenNamed("list-item") {
+			if t := item.textContent(); t != "" {
+				items = append(items, "• "+t)
+			}
+		}
+		return strings.Join(items, "\n")
+	}
+	return ""
+}
+
+func joinParagraphs(ps []*node) string {
+	var parts []string
+	for _, p := range ps {
+		if t := p.textContent(); t != "" {
+			parts = append(parts, t)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func convertTable(tw *node) Table {
+	t := Table{
+		ID:      tw.attr("id"),
+		Label:   tw.child("label").textContent(),
+		Caption: tw.child("caption").textContent(),
+	}
+	for _, tr := range tw.find("tr") {
+		var row []string
+		for _, cell := range tr.children {
+			if cell.name == "th" || cell.name == "td" {
+				row = append(row, cell.textContent())
+			}
+		}
+		if len(row) > 0 {
+			t.Rows = append(t.Rows, row)
+		}
+	}
+	return t
+}
+
+func convertReference(ref *node) Reference {
+	r := Reference{ID: ref.attr("id"), Label: ref.child("label").textContent()}
+
+	var cit *node
+	for _, name := range citationElements {
+		if found := ref.find(name); len(found) > 0 {
+			cit = found[0]
+			break
+		}
+	}
+	if cit == nil {
+		r.Citation = ref.textContent()
+		return r
+	}
+
+	for _, name := range cit.find("name") {
+		if surname := name.child("surname").textContent(); surname != "" {
+			r.Authors = append(r.Authors, surname)
+		}
+	}
+	r.Title = cit.child("article-title").textContent()
+	if r.Title == "" {
+		r.Title = cit.child("chapter-title").textContent()
+	}
+	r.Source = cit.child("source").textContent()
+	r.Year = cit.child("year").textContent()
+	r.Volume = cit.child("volume").textContent()
+	r.Issue = cit.child("issue").textContent()
+	r.Pages = cit.child("fpage").textContent()
+	if lpage := cit.child("lpage").textContent(); lpage != "" && r.Pages != "" {
+		r.Pages += "-" + lpage
+	}
+	for _, id := range cit.find("pub-id") {
+		switch id.attr("pub-id-type") {
+		case "doi":
+			r.DOI = id.textContent()
+		case "pmid":
+			r.PMID = id.textContent()
+		case "pmcid":
+			r.PMCID = id.textContent()
+		}
+	}
+
+	if cit.name == "mixed-citation" {
+		r.Citation = cit.textContent()
+	} else {
+		r.Citation = composeCitation(r)
+	}
+	return r
+}
+
+// composeCitation builds a Vancouver-style citation string for references
+// tagged with element-citation, whose markup has no punctuation.
+func composeCitation(r Reference) string {
+	var parts []string
+	if len(r.Authors) > 0 {
+		parts = append(parts, strings.Join(r.Authors, ", "))
+	}
+	if r.Title != "" {
+		parts = append(parts, r.Title)
+	}
+	if r.Source != "" {
+		parts = append(parts, r.Source)
+	}
+	detail := r.Year
+	if r.Volume != "" {
+		detail += ";" + r.Volume
+	}
+	if r.Issue != "" {
+		detail += "(" + r.Issue + ")"
+	}
+	if r.Pages != "" {
+		detail += ":" + r.Pages
+	}
+	if detail != "" {
+		parts = append(parts, detail)
+	}
+	for i, p := range parts {
+		parts[i] = strings.TrimRight(p, ". ")
+	}
+	return strings.Join(parts, ". ") + "."
+}
+
+// parseTree reads an XML document into a node tree, tolerating the HTML
+// entities and DOCTYPE declarations found in JATS.
+func parseTree(data []byte) (*node, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+
+	root := &node{}
+	stack := []*node{root}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		top := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: t.Name.Local, attrs: t.Attr}
+			top.children = append(top.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			top.children = append(top.children, &node{text: string(t)})
+		}
+	}
+	for _, c := range root.children {
+		if c.name != "" {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("empty document")
+}
+
+// attr returns the value of the named attribute, ignoring its namespace
+// (so "href" matches xlink:href).
+func (n *node) attr(name string) string {
+	if n == nil {
+		return ""
+	}
+	for _, a := range n.attrs {
+		if a.Name.Local == name {
+			return strings.TrimSpace(a.Value)
+		}
+	}
+	return ""
+}
+
+// child returns the first direct child element with the given name. It is
+// nil-safe so lookups can be chained.
+func (n *node) child(name string) *node {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *node) childrenNamed(name string) []*node {
+	if n == nil {
+		return nil
+	}
+	var out []*node
+	for _, c := range n.children {
+		if c.name == name {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// find returns all descendant elements with the given name in document
+// order, without searching inside matches.
+func (n *node) find(name string) []*node {
+	if n == nil {
+		return nil
+	}
+	var out []*node
+	for _, c := range n.children {
+		if c.name == name {
+			out = append(out, c)
+		} else if c.name != "" {
+			out = append(out, c.find(name)...)
+		}
+	}
+	return out
+}
+
+// textContent returns the element's text with whitespace collapsed,
+// leaving out figures and tables embedded in it.
+func (n *node) textContent() string {
+	if n == nil {
+		return ""
+	}
+	var b strings.Builder
+	n.writeText(&b)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func (n *node) writeText(b *strings.Builder) {
+	for _, c := range n.children {
+		switch {
+		case c.name == "":
+			b.WriteString(c.text)
+		case floatElements[c.name]:
+		case c.name == "name":
+			// <surname> and <given-names> are usually adjacent with no
+			// whitespace between them.
+			var parts []string
+			for _, part := range c.children {
+				if t := part.textContent(); part.name != "" && t != "" {
+					parts = append(parts, t)
+				}
+			}
+			b.WriteString(strings.Join(parts, " "))
+		case blockElements[c.name]:
+			b.WriteByte(' ')
+			c.writeText(b)
+			b.WriteByte(' ')
+		default:
+			c.writeText(b)
+		}
+	}
+}
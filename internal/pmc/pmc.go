@@ -0,0 +1,140 @@
+// Package pmc retrieves PubMed Central full text via NCBI E-utilities and
+// parses the JATS XML into sections, figures, tables and references.
+package pmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/ncbi"
+)
+
+// ErrNoArticle is returned when PMC has no record for the requested ID.
+var ErrNoArticle = errors.New("no PMC article found")
+
+// Article is a PMC full-text article.
+type Article struct {
+	PMCID      string      `json:"pmcid"`
+	PMID       string      `json:"pmid,omitempty"`
+	DOI        string      `json:"doi,omitempty"`
+	Title      string      `json:"title"`
+	Journal    string      `json:"journal,omitempty"`
+	Year       string      `json:"year,omitempty"`
+	Authors    []string    `json:"authors,omitempty"`
+	Abstract   string      `json:"abstract,omitempty"`
+	License    string      `json:"license,omitempty"`
+	Sections   []Section   `json:"sections,omitempty"`
+	Figures    []Figure    `json:"figures,omitempty"`
+	Tables     []Table     `json:"tables,omitempty"`
+	References []Reference `json:"references,omitempty"`
+}
+
+// HasBody reports whether the article includes body text. Publishers of
+// some PMC articles do not allow the full text to be downloaded as XML, in
+// which case only front matter and references are returned.
+func (a *Article) HasBody() bool {
+	return len(a.Sections) > 0
+}
+
+// Section is a body section; Sections holds nested subsections.
+type Section struct {
+	ID         string    `json:"id,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	Paragraphs []string  `json:"paragraphs,omitempty"`
+	Sections   []Section `json:"sections,omitempty"`
+}
+
+// Figure is a figure label and caption.
+type Figure struct {
+	ID      string `json:"id,omitempty"`
+	Label   string `json:"label,omitempty"`
+	Caption string `json:"caption,omitempty"`
+}
+
+// Table is a table caption with its cell text, header rows first.
+type Table struct {
+	ID      string     `json:"id,omitempty"`
+	Label   string     `json:"label,omitempty"`
+	Caption string     `json:"caption,omitempty"`
+	Rows    [][]string `json:"rows,omitempty"`
+}
+
+// Reference is one entry of the article's ref-list. Citation is the
+// citation text as printed; the remaining fields come from its markup when
+// the publisher tagged them.
+type Reference struct {
+	ID       string   `json:"id,omitempty"`
+	Label    string   `json:"label,omitempty"`
+	Citation string   `json:"citation"`
+	Authors  []string `json:"authors,omitempty"` // Last names
+	Title    string   `json:"title,omitempty"`
+	Source   string   `json:"source,omitempty"` // Journal or book title
+	Year     string   `json:"year,omitempty"`
+	Volume   string   `json:"volume,omitempty"`
+	Issue    string   `json:"issue,omitempty"`
+	Pages    string   `json:"pages,omitempty"`
+	DOI      string   `json:"doi,omitempty"`
+	PMID     string   `json:"pmid,omitempty"`
+	PMCID    string   `json:"pmcid,omitempty"`
+}
+
+// Client fetches PMC full text.
+// It embeds ncbi.BaseClient for shared rate limiting and common parameters.
+type Client struct {
+	*ncbi.BaseClient
+}
+
+// NewClient creates a new PMC client using an existing NCBI base client.
+func NewClient(base *ncbi.BaseClient) *Client {
+	return &Client{BaseClient: base}
+}
+
+// Fetch retrieves and parses the JATS XML for a PMCID ("PMC1234567" or the
+// bare number).
+func (c *Client) Fetch(ctx context.Context, pmcid string) (*Article, error) {
+	id, err := NormalizePMCID(pmcid)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("db", "pmc")
+	params.Set("id", strings.TrimPrefix(id, "PMC"))
+	params.Set("retmode", "xml")
+
+	body, err := c.DoGet(ctx, "efetch.fcgi", params)
+	if err != nil {
+		return nil, fmt.Errorf("PMC fetch failed: %w", err)
+	}
+
+	article, err := ParseJATS(body)
+	if err != nil {
+		return nil, err
+	}
+	if article.PMCID == "" {
+		article.PMCID = id
+	}
+	return article, nil
+}
+
+// NormalizePMCID returns id in canonical "PMC<digits>" form. The prefix is
+// optional and case-insensitive.
+func NormalizePMCID(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	digits := id
+	if len(id) > 3 && strings.EqualFold(id[:3], "PMC") {
+		digits = id[3:]
+	}
+	if digits == "" {
+		return "", fmt.Errorf("PMCID cannot be empty")
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("invalid PMCID %q", id)
+		}
+	}
+	return "PMC" + digits, nil
+}
@@ -0,0 +1,195 @@
+package pmc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/ncbi"
+)
+
+func loadTestdata(t *testing.T, filename string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", "testdata", filename))
+	if err != nil {
+		t.Fatalf("failed to load testdata/%s: %v", filename, err)
+	}
+	return data
+}
+
+func TestParseJATS_FrontMatter(t *testing.T) {
+	a, err := ParseJATS(loadTestdata(t, "pmc_jats.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if a.PMCID != "PMC10245678" || a.PMID != "37286542" || a.DOI != "10.1186/s13229-023-00555-1" {
+		t.Errorf("unexpected IDs: pmcid=%q pmid=%q doi=%q", a.PMCID, a.PMID, a.DOI)
+	}
+	if a.Title != "Metformin effects on cortical gamma oscillations in fragile X syndrome" {
+		t.Errorf("unexpected title %q", a.Title)
+	}
+	if a.Journal != "Molecular Autism" || a.Year != "2023" {
+		t.Errorf("unexpected journal/year: %q %q", a.Journal, a.Year)
+	}
+	if got := strings.Join(a.Authors, "|"); got != "Jane A Smith|John Doe|FXS Study Group" {
+		t.Errorf("unexpected authors %q", got)
+	}
+	if a.License != "https://creativecommons.org/licenses/by/4.0/" {
+		t.Errorf("unexpected license %q", a.License)
+	}
+	want := "Background: Fragile X syndrome (FXS) is the most common inherited cause of intellectual disability.\n\nResults: Metformin reduced gamma power by 12%."
+	if a.Abstract != want {
+		t.Errorf("unexpected abstract:\n%q\nwant\n%q", a.Abstract, want)
+	}
+}
+
+func TestParseJATS_Body(t *testing.T) {
+	a, err := ParseJATS(loadTestdata(t, "pmc_jats.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !a.HasBody() {
+		t.Fatal("expected body sections")
+	}
+	if len(a.Sections) != 3 {
+		t.Fatalf("expected 3 top-level sections, got %d", len(a.Sections))
+	}
+
+	intro := a.Sections[0]
+	if intro.Title != "Introduction" || len(intro.Paragraphs) != 2 {
+		t.Fatalf("unexpected introduction: %+v", intro)
+	}
+	if intro.Paragraphs[0] != "FXS is caused by silencing of FMR1 [1]." {
+		t.Errorf("unexpected paragraph %q", intro.Paragraphs[0])
+	}
+	if intro.Paragraphs[1] != "Elevated gamma power has been reported repeatedly [2]." {
+		t.Errorf("expected collapsed whitespace, got %q", intro.Paragraphs[1])
+	}
+
+	methods := a.Sections[1]
+	if len(methods.Paragraphs) != 0 || len(methods.Sections) != 1 {
+		t.Fatalf("expected one nested subsection, got %+v", methods)
+	}
+	participants := methods.Sections[0]
+	if participants.Title != "Participants" || len(participants.Paragraphs) != 2 {
+		t.Fatalf("unexpected subsection: %+v", participants)
+	}
+	if participants.Paragraphs[0] != "We enrolled 40 participants (see Fig. 1)." {
+		t.Errorf("expected figure left out of paragraph text, got %q", participants.Paragraphs[0])
+	}
+	if participants.Paragraphs[1] != "• Age 12 to 40 years\n• Confirmed FMR1 full mutation" {
+		t.Errorf("unexpected list rendering %q", participants.Paragraphs[1])
+	}
+
+	if len(a.Figures) != 1 || a.Figures[0].Label != "Fig. 1" || a.Figures[0].Caption != "Study flow. Participants screened and randomized." {
+		t.Errorf("unexpected figures: %+v", a.Figures)
+	}
+	if len(a.Tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(a.Tables))
+	}
+	tbl := a.Tables[0]
+	if tbl.Label != "Table 1" || tbl.Caption != "Baseline characteristics" || len(tbl.Rows) != 3 || tbl.Rows[0][0] != "Group" || tbl.Rows[2][1] != "20" {
+		t.Errorf("unexpected table: %+v", tbl)
+	}
+}
+
+func TestParseJATS_References(t *testing.T) {
+	a, err := ParseJATS(loadTestdata(t, "pmc_jats.xml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(a.References) != 3 {
+		t.Fatalf("expected 3 references, got %d", len(a.References))
+	}
+
+	mixed := a.References[0]
+	if mixed.Label != "1." || strings.Join(mixed.Authors, ",") != "Bear,Huber,Warren" {
+		t.Errorf("unexpected mixed-citation fields: %+v", mixed)
+	}
+	if mixed.Year != "2004" || mixed.Volume != "27" || mixed.Issue != "7" || mixed.Pages != "370-377" || mixed.DOI != "10.1016/j.tins.2004.04.009" {
+		t.Errorf("unexpected mixed-citation details: %+v", mixed)
+	}
+	if !strings.HasPrefix(mixed.Citation, "Bear MF, Huber KM, Warren ST. The mGluR theory") {
+		t.Errorf("expected citation text as printed, got %q", mixed.Citation)
+	}
+
+	element := a.References[1]
+	if element.PMID != "28596820" || element.Source != "Mol Autism" {
+		t.Errorf("unexpected element-citation fields: %+v", element)
+	}
+	want := "Ethridge, White. Neural synchronization deficits linked to cortical hyper-excitability in fragile X syndrome. Mol Autism. 2017;8:22."
+	if element.Citation != want {
+		t.Errorf("unexpected composed citation:\n%q\nwant\n%q", element.Citation, want)
+	}
+
+	if other := a.References[2]; other.Title != "" || !strings.HasPrefix(other.Citation, "World Health Organization") {
+		t.Errorf("unexpected untagged reference: %+v", other)
+	}
+}
+
+func TestParseJATS_NoBody(t *testing.T) {
+	data := []byte(`<pmc-articleset><article><front><article-meta>
+		<article-id pub-id-type="pmc">123</article-id>
+		<title-group><article-title>Restricted</article-title></title-group>
+		</article-meta></front>
+		<body><!--The publisher of this article does not allow downloading of the full text in XML form.--></body>
+		</article></pmc-articleset>`)
+	a, err := ParseJATS(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.HasBody() {
+		t.Errorf("expected no body, got %+v", a.Sections)
+	}
+	if a.PMCID != "PMC123" || a.Title != "Restricted" {
+		t.Errorf("unexpected front matter: %+v", a)
+	}
+}
+
+func TestParseJATS_NoArticle(t *testing.T) {
+	_, err := ParseJATS([]byte(`<pmc-articleset><error>ID list is empty!</error></pmc-articleset>`))
+	if !errors.Is(err, ErrNoArticle) {
+		t.Errorf("expected ErrNoArticle, got %v", err)
+	}
+}
+
+func TestNormalizePMCID(t *testing.T) {
+	for in, want := range map[string]string{"PMC123": "PMC123", "pmc123": "PMC123", "123": "PMC123", " PMC9 ": "PMC9"} {
+		got, err := NormalizePMCID(in)
+		if err != nil || got != want {
+			t.Errorf("NormalizePMCID(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "PMC", "PMC12a", "10.1000/x"} {
+		if _, err := NormalizePMCID(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	fixture := loadTestdata(t, "pmc_jats.xml")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if !strings.HasSuffix(r.URL.Path, "/efetch.fcgi") || q.Get("db") != "pmc" || q.Get("id") != "10245678" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := NewClient(ncbi.NewBaseClient(ncbi.WithBaseURL(srv.URL), ncbi.WithAPIKey("test")))
+	a, err := c.Fetch(context.Background(), "PMC10245678")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.PMCID != "PMC10245678" || len(a.References) != 3 {
+		t.Errorf("unexpected article: %s with %d references", a.PMCID, len(a.References))
+	}
+}
@@ -107,24 +107,29 @@ func ParseReferences(text string) ([]ParsedReference, error) {
 			body = body[len(m[0]):]
 		}
 
-		ref.PMID = ExtractPMID(body)
-		ref.DOI = ExtractDOI(body)
-
-		// Detect APA vs Vancouver format
-		isAPA := reYearParen.MatchString(body) && strings.Contains(body, ", &") || isAPAFormat(body)
-
-		if isAPA {
-			parseAPAReference(&ref, body)
-		} else {
-			parseVancouverReference(&ref, body)
-		}
-
+		parseReferenceBody(&ref, body)
 		refs = append(refs, ref)
 	}
 
 	return refs, nil
 }
 
+// parseReferenceBody fills ref from the text of a single reference with
+// any leading number already removed.
+func parseReferenceBody(ref *ParsedReference, body string) {
+	ref.PMID = ExtractPMID(body)
+	ref.DOI = ExtractDOI(body)
+
+	// Detect APA vs Vancouver format
+	isAPA := reYearParen.MatchString(body) && strings.Contains(body, ", &") || isAPAFormat(body)
+
+	if isAPA {
+		parseAPAReference(ref, body)
+	} else {
+		parseVancouverReference(ref, body)
+	}
+}
+
 // splitIntoBlocks splits cleaned lines into individual reference text blocks.
 func splitIntoBlocks(lines []string) []string {
 	// First check if lines are numbered (any format)
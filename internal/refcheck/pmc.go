@@ -0,0 +1,161 @@
+package refcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/pmc"
+)
+
+// PMCReference is one entry of a PMC article's reference list with the
+// PubMed record it resolved to, if any. PMID is set only for verified
+// matches; an unverified fuzzy match is reported as CandidatePMID.
+type PMCReference struct {
+	Index         int                `json:"index"`
+	Label         string             `json:"label,omitempty"`
+	Citation      string             `json:"citation"`
+	PMID          string             `json:"pmid,omitempty"`
+	CandidatePMID string             `json:"candidate_pmid,omitempty"`
+	Title         string             `json:"title,omitempty"` // Title of the matched PubMed record
+	Status        VerificationStatus `json:"status"`
+	Confidence    float64            `json:"confidence"`
+}
+
+// PMCReferenceList is the reference list of an open-access PMC article
+// resolved against PubMed. ELinkOnly lists pubmed_pubmed_refs PMIDs that
+// no ref-list entry resolved to.
+type PMCReferenceList struct {
+	SourceID   string         `json:"source_id"`
+	PMCID      string         `json:"pmcid"`
+	References []PMCReference `json:"references"`
+	ELinkOnly  []string       `json:"elink_only,omitempty"`
+}
+
+// PMIDs returns the PMIDs of verified references followed by ELinkOnly,
+// without duplicates. Candidate matches are left out.
+func (l *PMCReferenceList) PMIDs() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, r := range l.References {
+		if r.PMID != "" && !seen[r.PMID] {
+			seen[r.PMID] = true
+			ids = append(ids, r.PMID)
+		}
+	}
+	for _, id := range l.ELinkOnly {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// ParsePMCReferences converts JATS ref-list entries to parsed references.
+// The citation text is parsed as usual, then overridden by whatever fields
+// the publisher tagged, which are more reliable than text heuristics.
+func ParsePMCReferences(refs []pmc.Reference) []ParsedReference {
+	parsed := make([]ParsedReference, len(refs))
+	for i, r := range refs {
+		ref := ParsedReference{Raw: r.Citation, Index: i + 1}
+		parseReferenceBody(&ref, r.Citation)
+		if len(r.Authors) > 0 {
+			ref.Authors = r.Authors
+		}
+		override(&ref.Title, r.Title)
+		override(&ref.Journal, r.Source)
+		override(&ref.Year, r.Year)
+		override(&ref.Volume, r.Volume)
+		override(&ref.Issue, r.Issue)
+		override(&ref.Pages, r.Pages)
+		override(&ref.DOI, r.DOI)
+		override(&ref.PMID, r.PMID)
+		parsed[i] = ref
+	}
+	return parsed
+}
+
+func override(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
+
+// ResolvePMCReferences resolves a PMC article's ref-list through the usual
+// tiered PubMed queries. elink holds the article's pubmed_pubmed_refs
+// links, which may be nil; links not matched by any entry are kept in
+// ELinkOnly.
+func (r *Resolver) ResolvePMCReferences(ctx context.Context, sourceID string, article *pmc.Article, elink []eutils.LinkItem) *PMCReferenceList {
+	list := &PMCReferenceList{SourceID: sourceID, PMCID: article.PMCID}
+	results := r.ResolveAll(ctx, ParsePMCReferences(article.References))
+
+	matched := make(map[string]bool)
+	for i, vr := range results {
+		ref := PMCReference{
+			Index:      i + 1,
+			Label:      article.References[i].Label,
+			Citation:   article.References[i].Citation,
+			Status:     vr.Status,
+			Confidence: vr.Confidence,
+		}
+		if vr.Match != nil {
+			ref.Title = vr.Match.Title
+			if IsVerified(vr.Status) {
+				ref.PMID = vr.Match.PMID
+				matched[ref.PMID] = true
+			} else {
+				ref.CandidatePMID = vr.Match.PMID
+			}
+		}
+		list.References = append(list.References, ref)
+	}
+
+	for _, link := range elink {
+		if !matched[link.ID] {
+			list.ELinkOnly = append(list.ELinkOnly, link.ID)
+		}
+	}
+	return list
+}
+
+// FormatPMCReferencesJSON writes the list as indented JSON.
+func FormatPMCReferencesJSON(w io.Writer, list *PMCReferenceList) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(list)
+}
+
+// FormatPMCReferencesText writes one line per reference with its status
+// icon, matched PMID (or unverified candidate) and confidence, then any
+// ELink-only PMIDs.
+func FormatPMCReferencesText(w io.Writer, list *PMCReferenceList) error {
+	resolved := 0
+	for _, r := range list.References {
+		if r.PMID != "" {
+			resolved++
+		}
+	}
+	fmt.Fprintf(w, "References of PMID %s from %s: %d entries, %d resolved to PubMed\n\n",
+		list.SourceID, list.PMCID, len(list.References), resolved)
+
+	for _, r := range list.References {
+		pmid := "-"
+		if r.PMID != "" {
+			pmid = "PMID " + r.PMID
+		} else if r.CandidatePMID != "" {
+			pmid = "PMID " + r.CandidatePMID + "?"
+		}
+		fmt.Fprintf(w, "%s [%d] %s (%.0f%%) %s\n", statusIcon(r.Status), r.Index, pmid, r.Confidence*100, truncateStr(r.Citation, 100))
+	}
+
+	if len(list.ELinkOnly) > 0 {
+		fmt.Fprintf(w, "\nIn PubMed's reference links but not matched in the ref-list: %d\n", len(list.ELinkOnly))
+		for _, id := range list.ELinkOnly {
+			fmt.Fprintf(w, "  PMID %s\n", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package refcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
+	"github.com/henrybloomingdale/pubmed-cli/internal/pmc"
+)
+
+func loadPMCFixture(t *testing.T) *pmc.Article {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(testdataDir(), "pmc_jats.xml"))
+	if err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+	article, err := pmc.ParseJATS(data)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	return article
+}
+
+func TestParsePMCReferences(t *testing.T) {
+	refs := ParsePMCReferences(loadPMCFixture(t).References)
+	if len(refs) != 3 {
+		t.Fatalf("expected 3 references, got %d", len(refs))
+	}
+
+	bear := refs[0]
+	if bear.Index != 1 || bear.Journal != "Trends Neurosci" || bear.Year != "2004" || bear.Volume != "27" || bear.Pages != "370-377" {
+		t.Errorf("expected tagged fields, got %+v", bear)
+	}
+	if bear.DOI != "10.1016/j.tins.2004.04.009" || strings.Join(bear.Authors, ",") != "Bear,Huber,Warren" {
+		t.Errorf("unexpected DOI/authors: %+v", bear)
+	}
+
+	if refs[1].PMID != "28596820" || refs[1].Title == "" {
+		t.Errorf("expected tagged PMID and title, got %+v", refs[1])
+	}
+
+	// Untagged citations fall back to text parsing.
+	if other := refs[2]; other.Raw == "" || other.Year != "2019" {
+		t.Errorf("expected year parsed from citation text, got %+v", other)
+	}
+}
+
+func TestResolvePMCReferences(t *testing.T) {
+	resolver, srv := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case strings.Contains(r.URL.Path, "efetch"):
+			if q.Get("id") == "15219735" {
+				fmt.Fprint(w, bearArticleXML)
+				return
+			}
+			fmt.Fprint(w, `<PubmedArticleSet></PubmedArticleSet>`)
+		case strings.Contains(r.URL.Path, "esearch"):
+			ids := []string{}
+			if strings.Contains(q.Get("term"), "10.1016/j.tins.2004.04.009[doi]") {
+				ids = []string{"15219735"}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"esearchresult": map[string]interface{}{"count": fmt.Sprint(len(ids)), "idlist": ids},
+			})
+		case strings.Contains(r.URL.Path, "ecitmatch"):
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer srv.Close()
+
+	elink := []eutils.LinkItem{{ID: "15219735"}, {ID: "99999999"}}
+	list := resolver.ResolvePMCReferences(context.Background(), "37286542", loadPMCFixture(t), elink)
+
+	if list.SourceID != "37286542" || list.PMCID != "PMC10245678" || len(list.References) != 3 {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+	first := list.References[0]
+	if first.PMID != "15219735" || !IsVerified(first.Status) || first.Confidence < 0.9 || first.Label != "1." {
+		t.Errorf("expected first reference verified by DOI, got %+v", first)
+	}
+	if second := list.References[1]; second.PMID != "" || second.Status != StatusNotInPubMed {
+		t.Errorf("expected second reference unresolved, got %+v", second)
+	}
+	if strings.Join(list.ELinkOnly, ",") != "99999999" {
+		t.Errorf("expected only the unmatched ELink PMID, got %v", list.ELinkOnly)
+	}
+	if got := strings.Join(list.PMIDs(), ","); got != "15219735,99999999" {
+		t.Errorf("unexpected PMIDs %q", got)
+	}
+
+	var buf bytes.Buffer
+	if err := FormatPMCReferencesText(&buf, list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "3 entries, 1 resolved to PubMed") || !strings.Contains(out, "✓ [1] PMID 15219735") || !strings.Contains(out, "  PMID 99999999\n") {
+		t.Errorf("unexpected text output:\n%s", out)
+	}
+}
+
+func TestPMCReferenceList_SkipsCandidates(t *testing.T) {
+	list := &PMCReferenceList{
+		SourceID: "37286542",
+		PMCID:    "PMC10245678",
+		References: []PMCReference{
+			{Index: 1, Citation: "Bear MF et al.", PMID: "15219735", Status: StatusVerifiedExact, Confidence: 1},
+			{Index: 2, Citation: "Ethridge LE et al.", CandidatePMID: "28596820", Status: StatusCandidate, Confidence: 0.6},
+		},
+	}
+	if got := strings.Join(list.PMIDs(), ","); got != "15219735" {
+		t.Errorf("expected candidate to be left out of PMIDs, got %q", got)
+	}
+
+	var buf bytes.Buffer
+	if err := FormatPMCReferencesText(&buf, list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "2 entries, 1 resolved to PubMed") || !strings.Contains(out, "[2] PMID 28596820? (60%)") {
+		t.Errorf("unexpected text output:\n%s", out)
+	}
+}
@@ -31,6 +31,9 @@ func BuildReport(docPath string, results []VerifiedReference, audit *AuditResult
 		case StatusPossiblyFabricated:
 			r.Summary.PossiblyFabricated++
 		}
+		if v.Match != nil && v.Match.Retracted && IsVerified(v.Status) {
+			r.Summary.Retracted++
+		}
 	}
 	return r
 }
@@ -58,6 +61,9 @@ func FormatHuman(w io.Writer, report Report) error {
 	if s.PossiblyFabricated > 0 {
 		fmt.Fprintf(w, "  ⚠ Possibly fabricated:    %d\n", s.PossiblyFabricated)
 	}
+	if s.Retracted > 0 {
+		fmt.Fprintf(w, "  ⚠ Retracted (cited):      %d\n", s.Retracted)
+	}
 	fmt.Fprintln(w)
 
 	// Detail each reference.
@@ -93,6 +99,10 @@ func writeRefDetail(w io.Writer, vr VerifiedReference) {
 		fmt.Fprintf(w, "   Note:   %s\n", vr.Notes)
 	}
 
+	for _, warn := range vr.Warnings {
+		fmt.Fprintf(w, "   Warn:   %s\n", warn)
+	}
+
 	if len(vr.QueryTiers) > 0 {
 		fmt.Fprintf(w, "   Tiers:  %s\n", strings.Join(vr.QueryTiers, " → "))
 	}
@@ -127,7 +137,7 @@ func writeAuditSection(w io.Writer, audit *AuditResult) {
 
 // FormatCSV writes the report as CSV.
 func FormatCSV(w io.Writer, report Report) error {
-	fmt.Fprintln(w, "Index,Status,Confidence,PMID,DOI,Title,Corrections,Notes")
+	fmt.Fprintln(w, "Index,Status,Confidence,PMID,DOI,Title,Corrections,Notes,Warnings")
 	for _, vr := range report.Results {
 		pmid := ""
 		doi := ""
@@ -138,7 +148,7 @@ func FormatCSV(w io.Writer, report Report) error {
 			title = vr.Match.Title
 		}
 		corrections := strings.Join(vr.Corrections, "; ")
-		fmt.Fprintf(w, "%d,%s,%.2f,%s,%s,%s,%s,%s\n",
+		fmt.Fprintf(w, "%d,%s,%.2f,%s,%s,%s,%s,%s,%s\n",
 			vr.Parsed.Index,
 			vr.Status,
 			vr.Confidence,
@@ -147,6 +157,7 @@ func FormatCSV(w io.Writer, report Report) error {
 			csvEscape(title),
 			csvEscape(corrections),
 			csvEscape(vr.Notes),
+			csvEscape(strings.Join(vr.Warnings, "; ")),
 		)
 	}
 	return nil
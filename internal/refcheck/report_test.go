@@ -122,6 +122,46 @@ func TestFormatCSV(t *testing.T) {
 	}
 }
 
+func TestReport_RetractedReferences(t *testing.T) {
+	results := []VerifiedReference{
+		{
+			Parsed:   ParsedReference{Index: 1, Raw: "Doe J. Plasma biomarkers. 2019."},
+			Status:   StatusVerifiedExact,
+			Match:    &eutils.Article{PMID: "31234567", Title: "Plasma biomarkers", Retracted: true},
+			Warnings: []string{"Article has been retracted"},
+		},
+		{
+			Parsed: ParsedReference{Index: 2},
+			Status: StatusCandidate,
+			Match:  &eutils.Article{PMID: "2", Retracted: true},
+		},
+	}
+
+	report := BuildReport("test.docx", results, nil)
+	if report.Summary.Retracted != 1 {
+		t.Errorf("expected 1 retracted (verified only), got %d", report.Summary.Retracted)
+	}
+
+	var human bytes.Buffer
+	if err := FormatHuman(&human, report); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(human.String(), "Retracted (cited):      1") {
+		t.Errorf("expected retracted count in summary, got:\n%s", human.String())
+	}
+	if !strings.Contains(human.String(), "Warn:   Article has been retracted") {
+		t.Errorf("expected warning line, got:\n%s", human.String())
+	}
+
+	var csv bytes.Buffer
+	if err := FormatCSV(&csv, report); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(csv.String(), ",Warnings\n") || !strings.Contains(csv.String(), ",Article has been retracted\n") {
+		t.Errorf("expected Warnings column in CSV, got:\n%s", csv.String())
+	}
+}
+
 func TestFormatRIS(t *testing.T) {
 	results := []VerifiedReference{
 		{
@@ -3,6 +3,7 @@ package refcheck
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/henrybloomingdale/pubmed-cli/internal/eutils"
@@ -21,21 +22,22 @@ func NewResolver(client *eutils.Client) *Resolver {
 // Resolve attempts to verify a single reference via tiered PubMed queries.
 // It returns a VerifiedReference with the best match, candidates, and status.
 func (r *Resolver) Resolve(ctx context.Context, ref ParsedReference) VerifiedReference {
+	vr := r.resolve(ctx, ref, func() string {
+		return r.citMatch(ctx, []ParsedReference{ref})[0]
+	})
+	addCorrectionWarnings(&vr)
+	return vr
+}
+
+// resolve runs the tiers for ref. citPMID returns its ECitMatch result and
+// is only called when tier 0 cannot resolve the reference directly.
+func (r *Resolver) resolve(ctx context.Context, ref ParsedReference, citPMID func() string) VerifiedReference {
 	vr := VerifiedReference{Parsed: ref}
 
 	// Tier 0: Direct lookup by PMID or DOI.
 	if ref.PMID != "" {
 		if art := r.fetchByPMID(ctx, ref.PMID); art != nil {
-			score := ScoreMatch(ref, *art)
-			vr.Match = art
-			vr.Confidence = score.Total
-			vr.QueryTiers = append(vr.QueryTiers, "tier0_pmid")
-			if score.Total >= 0.95 {
-				vr.Status = StatusVerifiedExact
-			} else {
-				vr.Status = StatusVerifiedCorrected
-				vr.Corrections = describeDiffs(ref, *art)
-			}
+			setDirectMatch(&vr, ref, art, "tier0_pmid")
 			return vr
 		}
 		vr.QueryTiers = append(vr.QueryTiers, "tier0_pmid_miss")
@@ -43,21 +45,23 @@ func (r *Resolver) Resolve(ctx context.Context, ref ParsedReference) VerifiedRef
 
 	if ref.DOI != "" {
 		if art := r.searchByDOI(ctx, ref.DOI); art != nil {
-			score := ScoreMatch(ref, *art)
-			vr.Match = art
-			vr.Confidence = score.Total
-			vr.QueryTiers = append(vr.QueryTiers, "tier0_doi")
-			if score.Total >= 0.95 {
-				vr.Status = StatusVerifiedExact
-			} else {
-				vr.Status = StatusVerifiedCorrected
-				vr.Corrections = describeDiffs(ref, *art)
-			}
+			setDirectMatch(&vr, ref, art, "tier0_doi")
 			return vr
 		}
 		vr.QueryTiers = append(vr.QueryTiers, "tier0_doi_miss")
 	}
 
+	// Tier 0b: ECitMatch on journal/year/volume/first page/author.
+	if _, ok := citationQuery(ref, ""); ok {
+		if pmid := citPMID(); pmid != "" {
+			if art := r.fetchByPMID(ctx, pmid); art != nil {
+				setDirectMatch(&vr, ref, art, "tier0_citmatch")
+				return vr
+			}
+		}
+		vr.QueryTiers = append(vr.QueryTiers, "tier0_citmatch_miss")
+	}
+
 	// Tier 1: Title search.
 	if ref.Title != "" {
 		arts := r.searchByTitle(ctx, ref.Title)
@@ -119,22 +123,140 @@ func (r *Resolver) Resolve(ctx context.Context, ref ParsedReference) VerifiedRef
 	return vr
 }
 
-// ResolveAll verifies a batch of references sequentially.
+// ResolveAll verifies a batch of references sequentially, after resolving
+// all citation-style references with one batched ECitMatch request.
+// References with a PMID or DOI are left out of the batch, since tier 0
+// usually resolves them; one that misses gets its own ECitMatch request.
 // It respects context cancellation between references.
 func (r *Resolver) ResolveAll(ctx context.Context, refs []ParsedReference) []VerifiedReference {
+	// Zero-value entries keep the batch keys aligned with refs.
+	batch := make([]ParsedReference, len(refs))
+	for i, ref := range refs {
+		if ref.PMID == "" && ref.DOI == "" {
+			batch[i] = ref
+		}
+	}
+	citPMIDs := r.citMatch(ctx, batch)
 	results := make([]VerifiedReference, 0, len(refs))
-	for _, ref := range refs {
+	for i, ref := range refs {
 		if ctx.Err() != nil {
 			// Fill remaining with error status.
 			vr := VerifiedReference{Parsed: ref, Status: StatusNotInPubMed, Notes: "cancelled"}
 			results = append(results, vr)
 			continue
 		}
-		results = append(results, r.Resolve(ctx, ref))
+		vr := r.resolve(ctx, ref, func() string {
+			if ref.PMID != "" || ref.DOI != "" {
+				return r.citMatch(ctx, []ParsedReference{ref})[0]
+			}
+			return citPMIDs[i]
+		})
+		addCorrectionWarnings(&vr)
+		results = append(results, vr)
 	}
 	return results
 }
 
+// addCorrectionWarnings warns when a verified reference's match has been
+// retracted or carries an erratum, expression of concern or update notice.
+// Citing such a paper is not wrong, but the author should know about it.
+func addCorrectionWarnings(vr *VerifiedReference) {
+	if vr.Match == nil || !IsVerified(vr.Status) {
+		return
+	}
+	if vr.Match.Retracted {
+		vr.Warnings = append(vr.Warnings, "Article has been retracted")
+	}
+	for _, c := range vr.Match.Corrections {
+		w := c.Type.Label() + " published"
+		if c.Source != "" {
+			w += ": " + c.Source
+		}
+		if c.PMID != "" {
+			w += " (PMID " + c.PMID + ")"
+		}
+		vr.Warnings = append(vr.Warnings, w)
+	}
+}
+
+// IsVerified reports whether status confirms the reference exists in PubMed.
+func IsVerified(status VerificationStatus) bool {
+	switch status {
+	case StatusVerifiedExact, StatusVerifiedCorrected, StatusVerifiedByTitle:
+		return true
+	}
+	return false
+}
+
+// setDirectMatch records an article found by identifier lookup (PMID, DOI or
+// ECitMatch), marking it exact or corrected depending on the match score.
+func setDirectMatch(vr *VerifiedReference, ref ParsedReference, art *eutils.Article, tier string) {
+	score := ScoreMatch(ref, *art)
+	vr.Match = art
+	vr.Confidence = score.Total
+	vr.QueryTiers = append(vr.QueryTiers, tier)
+	if score.Total >= 0.95 {
+		vr.Status = StatusVerifiedExact
+	} else {
+		vr.Status = StatusVerifiedCorrected
+		vr.Corrections = describeDiffs(ref, *art)
+	}
+}
+
+// citMatch resolves every eligible reference with a single ECitMatch request.
+// The result is parallel to refs; "" means ineligible, unmatched or failed.
+func (r *Resolver) citMatch(ctx context.Context, refs []ParsedReference) []string {
+	pmids := make([]string, len(refs))
+
+	var queries []eutils.CitationQuery
+	for i, ref := range refs {
+		if q, ok := citationQuery(ref, strconv.Itoa(i)); ok {
+			queries = append(queries, q)
+		}
+	}
+	if len(queries) == 0 {
+		return pmids
+	}
+
+	matches, err := r.client.CitMatch(ctx, queries)
+	if err != nil {
+		return pmids
+	}
+	for key, pmid := range matches {
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(refs) {
+			pmids[i] = pmid
+		}
+	}
+	return pmids
+}
+
+// citationQuery builds an ECitMatch query for ref. It reports false when the
+// reference lacks a journal, year, or both volume and first page, since
+// ECitMatch cannot match such citations deterministically.
+func citationQuery(ref ParsedReference, key string) (eutils.CitationQuery, bool) {
+	firstPage := ref.Pages
+	if i := strings.IndexAny(firstPage, "-–—"); i >= 0 {
+		firstPage = firstPage[:i]
+	}
+	firstPage = strings.TrimSpace(firstPage)
+
+	if ref.Journal == "" || ref.Year == "" || (ref.Volume == "" && firstPage == "") {
+		return eutils.CitationQuery{}, false
+	}
+
+	q := eutils.CitationQuery{
+		Journal:   ref.Journal,
+		Year:      ref.Year,
+		Volume:    ref.Volume,
+		FirstPage: firstPage,
+		Key:       key,
+	}
+	if len(ref.Authors) > 0 {
+		q.Author = ref.Authors[0]
+	}
+	return q, true
+}
+
 // fetchByPMID fetches a single article by PMID.
 func (r *Resolver) fetchByPMID(ctx context.Context, pmid string) *eutils.Article {
 	arts, err := r.client.Fetch(ctx, []string{pmid})
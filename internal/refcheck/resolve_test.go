@@ -101,6 +101,10 @@ func TestResolve_Tier0_PMID(t *testing.T) {
 
 func TestResolve_Tier0_DOI(t *testing.T) {
 	resolver, srv := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "ecitmatch") {
+			t.Error("unexpected ECitMatch request for a reference resolved by DOI")
+			return
+		}
 		if strings.Contains(r.URL.Path, "esearch") {
 			// Return a search result with one PMID.
 			w.Header().Set("Content-Type", "application/json")
@@ -127,6 +131,9 @@ func TestResolve_Tier0_DOI(t *testing.T) {
 		Authors: []string{"Bear", "Huber", "Warren"},
 		Year:    "2004",
 		Title:   "The mGluR theory of fragile X mental retardation.",
+		Journal: "Trends Neurosci",
+		Volume:  "27",
+		Pages:   "370-7",
 		DOI:     "10.1016/j.tins.2004.04.009",
 	}
 
@@ -179,6 +186,111 @@ func TestResolve_Tier1_Title(t *testing.T) {
 	}
 }
 
+func TestResolveAll_CitMatchTier(t *testing.T) {
+	var citmatchCalls, searches int
+	resolver, srv := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "ecitmatch"):
+			citmatchCalls++
+			lines := strings.Split(r.URL.Query().Get("bdata"), "\r")
+			if len(lines) != 2 {
+				t.Errorf("expected both references without a DOI in one ECitMatch request, got %d", len(lines))
+			}
+			fmt.Fprint(w, "Trends Neurosci|2004|27|370|Bear|0|15219735\n"+
+				"Made Up J|2021|9|99|Nobody|1|NOT_FOUND\n")
+		case strings.Contains(r.URL.Path, "efetch"):
+			fmt.Fprint(w, bearArticleXML)
+		case strings.Contains(r.URL.Path, "esearch"):
+			ids := []string{}
+			if strings.HasSuffix(r.URL.Query().Get("term"), "[doi]") {
+				ids = []string{"15219735"}
+			} else {
+				searches++
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"esearchresult": map[string]interface{}{"count": fmt.Sprint(len(ids)), "idlist": ids},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer srv.Close()
+
+	refs := []ParsedReference{
+		{
+			Index:   1,
+			Authors: []string{"Bear", "Huber", "Warren"},
+			Year:    "2004",
+			Title:   "The mGluR theory of fragile X mental retardation",
+			Journal: "Trends Neurosci",
+			Volume:  "27",
+			Pages:   "370-7",
+		},
+		{
+			Index:   2,
+			Authors: []string{"Nobody"},
+			Year:    "2021",
+			Title:   "An invented paper",
+			Journal: "Made Up J",
+			Volume:  "9",
+			Pages:   "99-104",
+		},
+		{
+			Index:   3,
+			Authors: []string{"Bear", "Huber", "Warren"},
+			Year:    "2004",
+			Journal: "Trends Neurosci",
+			Volume:  "27",
+			Pages:   "370-7",
+			DOI:     "10.1016/j.tins.2004.04.009",
+		},
+	}
+
+	results := resolver.ResolveAll(context.Background(), refs)
+	if citmatchCalls != 1 {
+		t.Errorf("expected 1 ECitMatch request, got %d", citmatchCalls)
+	}
+
+	first := results[0]
+	if first.Match == nil || first.Match.PMID != "15219735" {
+		t.Fatalf("expected ECitMatch to resolve PMID 15219735, got %+v", first.Match)
+	}
+	if len(first.QueryTiers) != 1 || first.QueryTiers[0] != "tier0_citmatch" {
+		t.Errorf("expected [tier0_citmatch], got %v", first.QueryTiers)
+	}
+	if first.Status != StatusVerifiedExact {
+		t.Errorf("expected VERIFIED_EXACT, got %s", first.Status)
+	}
+
+	second := results[1]
+	if len(second.QueryTiers) == 0 || second.QueryTiers[0] != "tier0_citmatch_miss" {
+		t.Errorf("expected tier0_citmatch_miss first, got %v", second.QueryTiers)
+	}
+	if searches == 0 {
+		t.Error("expected unmatched reference to fall through to search tiers")
+	}
+	if third := results[2]; len(third.QueryTiers) != 1 || third.QueryTiers[0] != "tier0_doi" {
+		t.Errorf("expected DOI reference resolved by tier 0 alone, got %v", third.QueryTiers)
+	}
+}
+
+func TestCitationQuery_Eligibility(t *testing.T) {
+	q, ok := citationQuery(ParsedReference{Authors: []string{"Bear"}, Year: "2004", Journal: "Trends Neurosci", Pages: "370-7"}, "k")
+	if !ok {
+		t.Fatal("expected reference with journal, year and pages to be eligible")
+	}
+	if q.FirstPage != "370" || q.Author != "Bear" || q.Key != "k" {
+		t.Errorf("unexpected query: %+v", q)
+	}
+
+	if _, ok := citationQuery(ParsedReference{Year: "2004", Volume: "27"}, ""); ok {
+		t.Error("expected reference without a journal to be ineligible")
+	}
+	if _, ok := citationQuery(ParsedReference{Journal: "Nature", Year: "2004"}, ""); ok {
+		t.Error("expected reference without volume or pages to be ineligible")
+	}
+}
+
 func TestResolve_NotInPubMed(t *testing.T) {
 	// Server returns empty results for everything.
 	resolver, srv := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
@@ -279,6 +391,68 @@ func TestResolve_PMIDFetchFails(t *testing.T) {
 	}
 }
 
+func TestResolve_RetractedMatchWarns(t *testing.T) {
+	retracted := strings.Replace(bearArticleXML, "    </MedlineCitation>", `      <CommentsCorrectionsList>
+        <CommentsCorrections RefType="RetractionIn">
+          <RefSource>Trends Neurosci. 2005;28(1):1</RefSource>
+          <PMID>15600001</PMID>
+        </CommentsCorrections>
+      </CommentsCorrectionsList>
+    </MedlineCitation>`, 1)
+
+	resolver, srv := newTestResolver(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "efetch") {
+			w.Header().Set("Content-Type", "text/xml")
+			fmt.Fprint(w, retracted)
+			return
+		}
+		http.NotFound(w, r)
+	})
+	defer srv.Close()
+
+	ref := ParsedReference{
+		Index:   1,
+		PMID:    "15219735",
+		Authors: []string{"Bear", "Huber", "Warren"},
+		Year:    "2004",
+		Title:   "The mGluR theory of fragile X mental retardation.",
+		DOI:     "10.1016/j.tins.2004.04.009",
+	}
+
+	result := resolver.Resolve(context.Background(), ref)
+
+	// Retraction does not change the verification status.
+	if result.Status != StatusVerifiedExact {
+		t.Errorf("expected VERIFIED_EXACT, got %s", result.Status)
+	}
+	if result.Match == nil || !result.Match.Retracted {
+		t.Fatal("expected retracted match")
+	}
+	want := []string{
+		"Article has been retracted",
+		"Retraction published: Trends Neurosci. 2005;28(1):1 (PMID 15600001)",
+	}
+	if len(result.Warnings) != len(want) {
+		t.Fatalf("expected warnings %v, got %v", want, result.Warnings)
+	}
+	for i := range want {
+		if result.Warnings[i] != want[i] {
+			t.Errorf("warning %d: expected %q, got %q", i, want[i], result.Warnings[i])
+		}
+	}
+}
+
+func TestAddCorrectionWarnings_SkipsUnverified(t *testing.T) {
+	vr := VerifiedReference{
+		Status: StatusCandidate,
+		Match:  &eutils.Article{PMID: "1", Retracted: true},
+	}
+	addCorrectionWarnings(&vr)
+	if len(vr.Warnings) != 0 {
+		t.Errorf("expected no warnings for candidate match, got %v", vr.Warnings)
+	}
+}
+
 func TestSignificantWords(t *testing.T) {
 	tests := []struct {
 		text string
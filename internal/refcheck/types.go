@@ -68,6 +68,7 @@ type VerifiedReference struct {
 	Candidates  []eutils.Article   `json:"candidates,omitempty"` // Runner-up matches
 	QueryTiers  []string           `json:"query_tiers,omitempty"` // Tiers attempted
 	Notes       string             `json:"notes,omitempty"`
+	Warnings    []string           `json:"warnings,omitempty"` // Retraction and correction notices on the match
 }
 
 // CitationUsage tracks where an in-text citation appears in the document body.
@@ -103,4 +104,5 @@ type ReportSummary struct {
 	Candidate         int `json:"candidate"`
 	NotInPubMed       int `json:"not_in_pubmed"`
 	PossiblyFabricated int `json:"possibly_fabricated"`
+	Retracted          int `json:"retracted"` // Verified references whose match is retracted
 }